@@ -0,0 +1,101 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterRouteMonitorSpec defines the desired state of ClusterRouteMonitor
+type ClusterRouteMonitorSpec struct {
+	// NamespaceSelector selects the namespaces whose Routes are monitored, all namespaces are selected when it is empty
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// RouteSelector selects the Routes that are monitored in the selected namespaces
+	RouteSelector metav1.LabelSelector `json:"routeSelector"`
+
+	// Interval is how often the Routes are probed, defaults to 30s
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// ScrapeTimeout is how long a probe may take before it fails, defaults to 15s.
+	// It has to be smaller than the Interval
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+
+	// HealthPath is appended to the path of the Routes when probing, e.g. /healthz
+	// +optional
+	HealthPath string `json:"healthPath,omitempty"`
+
+	// Module is the blackbox exporter module used to probe the Routes, defaults to http_2xx
+	// +optional
+	Module string `json:"module,omitempty"`
+
+	// HTTP customizes how the Routes are probed, the operator generates a dedicated module from it.
+	// It can only be combined with http modules
+	// +optional
+	HTTP *RouteMonitorHTTPSpec `json:"http,omitempty"`
+}
+
+// ClusterRouteMonitorStatus defines the observed state of ClusterRouteMonitor
+type ClusterRouteMonitorStatus struct {
+	// Namespaces are the namespaces that hold selected Routes, each of them has a RouteMonitor owned by the ClusterRouteMonitor
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// ObservedGeneration is the generation of the ClusterRouteMonitor that was last reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe whether the RouteMonitors of the selected namespaces are in place
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Message",type=string,priority=1,JSONPath=`.status.conditions[?(@.type=="Ready")].message`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterRouteMonitor is the Schema for the clusterroutemonitors API
+type ClusterRouteMonitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterRouteMonitorSpec   `json:"spec,omitempty"`
+	Status ClusterRouteMonitorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterRouteMonitorList contains a list of ClusterRouteMonitor
+type ClusterRouteMonitorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterRouteMonitor `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterRouteMonitor{}, &ClusterRouteMonitorList{})
+}
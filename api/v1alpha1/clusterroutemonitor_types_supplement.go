@@ -0,0 +1,76 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// TemplateForRouteMonitorName returns the name of the RouteMonitors the ClusterRouteMonitor creates in the selected namespaces
+func (r ClusterRouteMonitor) TemplateForRouteMonitorName() string {
+	return fmt.Sprintf("cluster-%s", r.Name)
+}
+
+// TemplateForRouteMonitor returns the RouteMonitor probing the selected Routes of a namespace
+func (r ClusterRouteMonitor) TemplateForRouteMonitor(namespace string) RouteMonitor {
+	routeSelector := r.Spec.RouteSelector.DeepCopy()
+	var httpSpec *RouteMonitorHTTPSpec
+	if r.Spec.HTTP != nil {
+		httpSpec = r.Spec.HTTP.DeepCopy()
+	}
+	routeMonitor := RouteMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.TemplateForRouteMonitorName(),
+			Namespace: namespace,
+			Labels: map[string]string{
+				routemonitorconst.ClusterRouteMonitorLabel: r.Name,
+			},
+		},
+		Spec: RouteMonitorSpec{
+			RouteSelector: routeSelector,
+			Interval:      r.Spec.Interval,
+			ScrapeTimeout: r.Spec.ScrapeTimeout,
+			HealthPath:    r.Spec.HealthPath,
+			Module:        r.Spec.Module,
+			HTTP:          httpSpec,
+		},
+	}
+	// the defaulting webhook fills in the same settings, the spec has to match to not be updated over and over
+	routeMonitor.Default()
+	return routeMonitor
+}
+
+// GetNamespaceSelector returns the parsed NamespaceSelector, selecting every namespace when it is not set
+func (r ClusterRouteMonitor) GetNamespaceSelector() (labels.Selector, error) {
+	if r.Spec.NamespaceSelector == nil {
+		return labels.Everything(), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(r.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot parse namespaceSelector: %v", customerrors.InvalidCR, err)
+	}
+	return selector, nil
+}
+
+// GetRouteSelector returns the parsed RouteSelector
+func (r ClusterRouteMonitor) GetRouteSelector() (labels.Selector, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&r.Spec.RouteSelector)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot parse routeSelector: %v", customerrors.InvalidCR, err)
+	}
+	return selector, nil
+}
+
+// SetCondition sets a condition on the status for the current generation of the ClusterRouteMonitor
+func (r *ClusterRouteMonitor) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	SetCondition(&r.Status.Conditions, Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: r.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
@@ -0,0 +1,97 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition describes one aspect of the state of a RouteMonitor, it mirrors the upstream metav1.Condition
+type Condition struct {
+	// Type of the condition, e.g. Ready
+	Type string `json:"type"`
+	// Status of the condition, one of True, False or Unknown
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status metav1.ConditionStatus `json:"status"`
+	// ObservedGeneration is the generation of the RouteMonitor the condition was set for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastTransitionTime is the last time the condition changed its status
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Reason is a CamelCase word explaining the status
+	Reason string `json:"reason"`
+	// Message is a human readable explanation of the status
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// Types of the conditions of a RouteMonitor
+const (
+	// ConditionRouteFound is True when the Route of the spec exists
+	ConditionRouteFound = "RouteFound"
+	// ConditionURLResolved is True when the urls to probe were extracted from the Route
+	ConditionURLResolved = "URLResolved"
+	// ConditionBlackboxExporterReady is True when the resources of the blackbox exporter exist
+	ConditionBlackboxExporterReady = "BlackboxExporterReady"
+	// ConditionServiceMonitorReady is True when the ServiceMonitor, or the Probe of the Probe backend, probing the Route exists
+	ConditionServiceMonitorReady = "ServiceMonitorReady"
+	// ConditionAlertingReady is True when the PrometheusRule of a RouteMonitor with alerting exists, or the RouteMonitor has no alerting
+	ConditionAlertingReady = "AlertingReady"
+	// ConditionReady is True when all other conditions are True
+	ConditionReady = "Ready"
+)
+
+// Reasons of the conditions of a RouteMonitor
+const (
+	ReasonRouteFound            = "RouteFound"
+	ReasonRouteNotFound         = "RouteNotFound"
+	ReasonURLResolved           = "URLResolved"
+	ReasonNoAdmittedIngress     = "NoAdmittedIngress"
+	ReasonNoHost                = "NoHost"
+	ReasonResourcesExist        = "ResourcesExist"
+	ReasonServiceMonitorPatched = "ServiceMonitorPatched"
+	ReasonResourceForeign       = "ResourceForeign"
+	ReasonAlertingDisabled      = "AlertingDisabled"
+	ReasonInvalidSpec           = "InvalidSpec"
+	ReasonReconcileFailed       = "ReconcileFailed"
+	ReasonReady                 = "Ready"
+	ReasonWaitingForCondition   = "WaitingForCondition"
+)
+
+// FindCondition returns the condition of the given type, or nil if it was never set
+func FindCondition(conditions []Condition, conditionType string) *Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds newCondition to conditions or replaces the one of the same type.
+// LastTransitionTime is only moved when the status changes, so an unchanged condition doesn't cause a write
+func SetCondition(conditions *[]Condition, newCondition Condition) {
+	existingCondition := FindCondition(*conditions, newCondition.Type)
+	if existingCondition == nil {
+		if newCondition.LastTransitionTime.IsZero() {
+			newCondition.LastTransitionTime = metav1.Now()
+		}
+		*conditions = append(*conditions, newCondition)
+		return
+	}
+
+	if existingCondition.Status != newCondition.Status {
+		existingCondition.Status = newCondition.Status
+		if newCondition.LastTransitionTime.IsZero() {
+			newCondition.LastTransitionTime = metav1.Now()
+		}
+		existingCondition.LastTransitionTime = newCondition.LastTransitionTime
+	}
+	existingCondition.Reason = newCondition.Reason
+	existingCondition.Message = newCondition.Message
+	existingCondition.ObservedGeneration = newCondition.ObservedGeneration
+}
+
+// IsConditionTrue verifies that the condition of the given type is set and True
+func IsConditionTrue(conditions []Condition, conditionType string) bool {
+	condition := FindCondition(conditions, conditionType)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
@@ -27,16 +27,119 @@ import (
 type RouteMonitorSpec struct {
 	// Route is the resource that holds the name and Namespace of the Route to monitor
 	Route RouteMonitorRouteSpec `json:"route,omitempty"`
+
+	// RouteSelector selects every Route in the namespace of the RouteMonitor, it is an alternative to Route.
+	// Each selected Route gets its own targets
+	// +optional
+	RouteSelector *metav1.LabelSelector `json:"routeSelector,omitempty"`
+
+	// URL is probed as is instead of the urls of a Route, it is an alternative to Route and RouteSelector.
+	// It is meant for endpoints that are not exposed by a Route
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Interval is how often the Route is probed, defaults to 30s
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// ScrapeTimeout is how long a probe may take before it fails, defaults to 15s.
+	// It has to be smaller than the Interval
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+
+	// HealthPath is appended to the path of the Route when probing, e.g. /healthz
+	// +optional
+	HealthPath string `json:"healthPath,omitempty"`
+
+	// Module is the blackbox exporter module used to probe the Route, defaults to http_2xx
+	// +optional
+	Module string `json:"module,omitempty"`
+
+	// HTTP customizes how the Route is probed, the operator generates a dedicated module from it.
+	// It can only be combined with http modules
+	// +optional
+	HTTP *RouteMonitorHTTPSpec `json:"http,omitempty"`
+
+	// Backend is the Prometheus Operator resource the probes are scraped with, ServiceMonitor or Probe.
+	// Defaults to the backend of the probe defaults of the operator
+	// +kubebuilder:validation:Enum=ServiceMonitor;Probe
+	// +optional
+	Backend string `json:"backend,omitempty"`
+
+	// Alerting creates a PrometheusRule next to the ServiceMonitor, alerting when the probes fail
+	// +optional
+	Alerting *RouteMonitorAlerting `json:"alerting,omitempty"`
 }
 
+// Backends the probes of a RouteMonitor are scraped with
+const (
+	// BackendServiceMonitor scrapes the exporter Service with a ServiceMonitor passing the target as a parameter
+	BackendServiceMonitor = "ServiceMonitor"
+	// BackendProbe scrapes the exporter with a Probe, which sets the target as the instance label
+	BackendProbe = "Probe"
+)
+
 // RouteMonitorStatus defines the observed state of RouteMonitor
 type RouteMonitorStatus struct {
-	// RouteURL is the url extracted from the Route resource
-	RouteURL string `json:"routeURL,omitempty"`
+	// Routes are the names of the Routes that are monitored, either the Route of the spec or the ones selected by the RouteSelector.
+	// It is empty when the URL of the spec is probed
+	Routes []string `json:"routes,omitempty"`
+
+	// RouteURLs are the urls extracted from the admitted ingresses of the monitored Routes, or the URL of the spec
+	RouteURLs []RouteMonitorURL `json:"routeURLs,omitempty"`
+
+	// ObservedGeneration is the generation of the RouteMonitor that was last reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe whether the Route is probed, and why not if it isn't
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// EffectiveProbe shows the probe settings in use: the ones of the spec, and the defaults of the operator for those left empty
+	// +optional
+	EffectiveProbe RouteMonitorEffectiveProbe `json:"effectiveProbe,omitempty"`
+}
+
+// RouteMonitorURL is the url of a single admitted ingress of a Route
+type RouteMonitorURL struct {
+	// RouteName is the name of the Route the ingress belongs to
+	RouteName string `json:"routeName,omitempty"`
+	// RouterName is the name of the router that admitted the ingress
+	RouterName string `json:"routerName,omitempty"`
+	// URL is the full url that is probed
+	URL string `json:"url"`
+	// Scheme is the scheme of the URL, https when the Route is secured by TLS
+	Scheme string `json:"scheme,omitempty"`
+	// Host is the host of the URL, taken from the ingress of the Route
+	Host string `json:"host,omitempty"`
+	// Path is the path of the URL, the path of the Route joined with the HealthPath
+	Path string `json:"path,omitempty"`
+}
+
+// RouteMonitorEffectiveProbe holds the probe settings the RouteMonitor is probed with
+type RouteMonitorEffectiveProbe struct {
+	// Interval is how often the target is probed
+	Interval string `json:"interval,omitempty"`
+	// ScrapeTimeout is how long a probe may take before it fails
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+	// Module is the blackbox exporter module the target is probed with
+	Module string `json:"module,omitempty"`
+	// Backend is the resource the probes are scraped with
+	Backend string `json:"backend,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Route",type=string,JSONPath=`.spec.route.name`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Message",type=string,priority=1,JSONPath=`.status.conditions[?(@.type=="Ready")].message`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // RouteMonitor is the Schema for the routemonitors API
 type RouteMonitor struct {
@@ -1,12 +1,22 @@
 package v1alpha1
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
 
 	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
 	"github.com/openshift/route-monitor-operator/pkg/const/blackbox"
+	"github.com/openshift/route-monitor-operator/pkg/util/blackboxconfig"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
 	utilfinalizer "github.com/openshift/route-monitor-operator/pkg/util/finalizer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type RouteMonitorRouteSpec struct {
@@ -16,11 +26,109 @@ type RouteMonitorRouteSpec struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+type RouteMonitorHTTPSpec struct {
+	// Method is the HTTP method of the probe request, defaults to the method of the module
+	// +kubebuilder:validation:Enum=GET;HEAD;POST;PUT;PATCH;DELETE;OPTIONS
+	// +optional
+	Method string `json:"method,omitempty"`
+	// Headers are sent with the probe request
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body is sent with the probe request
+	// +optional
+	Body string `json:"body,omitempty"`
+	// ValidStatusCodes are the status codes a successful probe can return, defaults to any 2xx
+	// +optional
+	ValidStatusCodes []int `json:"validStatusCodes,omitempty"`
+	// FailIfBodyMatchesRegexp fails the probe if the response body matches one of the expressions
+	// +optional
+	FailIfBodyMatchesRegexp []string `json:"failIfBodyMatchesRegexp,omitempty"`
+	// FailIfBodyNotMatchesRegexp fails the probe if the response body does not match one of the expressions
+	// +optional
+	FailIfBodyNotMatchesRegexp []string `json:"failIfBodyNotMatchesRegexp,omitempty"`
+	// FollowRedirects decides if the probe follows redirects, defaults to true
+	// +optional
+	FollowRedirects *bool `json:"followRedirects,omitempty"`
+}
+
+// RouteMonitorAlerting configures the alert raised when the probes of the RouteMonitor fail
+type RouteMonitorAlerting struct {
+	// For is how long the probes have to fail before the alert fires, defaults to 5m
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	For string `json:"for,omitempty"`
+	// Severity is set as the severity label of the alert, defaults to warning
+	// +optional
+	Severity string `json:"severity,omitempty"`
+	// Labels are added to the alert, e.g. to route it to a team
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
 // TemplateForServiceMonitorName return the generated name from the RouteMonitor.
-// The name is joined by the name and the namespace to create a unique ServiceMonitor for each RouteMonitor
+// The name and the namespace are joined for readability and suffixed by a hash of both, as joining alone is ambiguous:
+// `a-b` in `c` and `a` in `b-c` would both become `a-b-c`.
+// Long names are truncated, so the ServiceMonitor name can be used as a label value
 func (r *RouteMonitor) TemplateForServiceMonitorName() types.NamespacedName {
+	// namespaces cannot contain '/', so the hashed key is unique
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(r.Namespace+"/"+r.Name)))[:serviceMonitorNameHashLength]
+	prefix := fmt.Sprintf("%s-%s", r.Name, r.Namespace)
+	if maxLength := validation.LabelValueMaxLength - serviceMonitorNameHashLength - 1; len(prefix) > maxLength {
+		prefix = strings.TrimRight(prefix[:maxLength], "-.")
+	}
+	return types.NamespacedName{Name: prefix + "-" + hash, Namespace: r.TemplateForServiceMonitorNamespace()}
+}
+
+// TemplateForServiceMonitorNamespace returns the namespace of the ServiceMonitor, next to the exporter or the RouteMonitor
+func (r *RouteMonitor) TemplateForServiceMonitorNamespace() string {
+	if blackbox.ServiceMonitorPlacement == blackbox.ServiceMonitorInRouteMonitorNamespace {
+		return r.Namespace
+	}
+	return blackbox.BlackBoxNamespace
+}
+
+// serviceMonitorNameHashLength is the number of hex characters of the hash suffixing the ServiceMonitor name
+const serviceMonitorNameHashLength = 10
+
+// TemplateForLegacyServiceMonitorName returns the name ServiceMonitors were created with before their names were hashed,
+// back when they were always placed in the default namespace of the exporter
+func (r *RouteMonitor) TemplateForLegacyServiceMonitorName() types.NamespacedName {
 	serviceMonitorName := fmt.Sprintf("%s-%s", r.Name, r.Namespace)
-	return types.NamespacedName{Name: serviceMonitorName, Namespace: blackbox.BlackBoxNamespace}
+	return types.NamespacedName{Name: serviceMonitorName, Namespace: blackbox.DefaultBlackBoxNamespace}
+}
+
+// TemplateForServiceMonitorOwnerLabels returns the labels that mark a ServiceMonitor as created for the RouteMonitor
+func (r RouteMonitor) TemplateForServiceMonitorOwnerLabels() map[string]string {
+	return map[string]string{routemonitorconst.ServiceMonitorOwnerUIDLabel: string(r.UID)}
+}
+
+// TemplateForServiceMonitorOwnerAnnotations returns the annotations that point from a ServiceMonitor back to the RouteMonitor.
+// Names can be longer than label values, so they are kept in annotations
+func (r RouteMonitor) TemplateForServiceMonitorOwnerAnnotations() map[string]string {
+	return map[string]string{
+		routemonitorconst.ServiceMonitorOwnerNamespaceAnnotation: r.Namespace,
+		routemonitorconst.ServiceMonitorOwnerNameAnnotation:      r.Name,
+	}
+}
+
+// OwnsGeneratedResource verifies that the ServiceMonitor, Probe or PrometheusRule was created for this RouteMonitor, and not for another one
+func (r RouteMonitor) OwnsGeneratedResource(resource metav1.Object) bool {
+	uid, ok := resource.GetLabels()[routemonitorconst.ServiceMonitorOwnerUIDLabel]
+	return ok && uid == string(r.UID)
+}
+
+// RouteMonitorOfServiceMonitor returns the RouteMonitor a ServiceMonitor was created for, read from its owner annotations.
+// It returns false for ServiceMonitors the operator didn't create
+func RouteMonitorOfServiceMonitor(serviceMonitor metav1.Object) (types.NamespacedName, bool) {
+	if _, ok := serviceMonitor.GetLabels()[routemonitorconst.ServiceMonitorOwnerUIDLabel]; !ok {
+		return types.NamespacedName{}, false
+	}
+	annotations := serviceMonitor.GetAnnotations()
+	owner := types.NamespacedName{
+		Namespace: annotations[routemonitorconst.ServiceMonitorOwnerNamespaceAnnotation],
+		Name:      annotations[routemonitorconst.ServiceMonitorOwnerNameAnnotation],
+	}
+	return owner, owner.Namespace != "" && owner.Name != ""
 }
 
 // WasDeleteRequested verifies if the resource was requested for deletion
@@ -32,3 +140,250 @@ func (r RouteMonitor) WasDeleteRequested() bool {
 func (r RouteMonitor) HasFinalizer() bool {
 	return utilfinalizer.Contains(r.ObjectMeta.Finalizers, routemonitorconst.FinalizerKey)
 }
+
+// ApplyProbeDefaults fills the probe settings left empty with the defaults of the RouteMonitorOperatorConfig.
+// It only changes the RouteMonitor in memory: the stored spec keeps them empty, so it follows later changes of the defaults
+func (r *RouteMonitor) ApplyProbeDefaults(defaults ProbeDefaults) {
+	if r.Spec.Interval == "" {
+		r.Spec.Interval = defaults.Interval
+	}
+	if r.Spec.ScrapeTimeout == "" {
+		r.Spec.ScrapeTimeout = defaults.ScrapeTimeout
+	}
+	if r.Spec.Module == "" {
+		r.Spec.Module = defaults.Module
+	}
+	if r.Spec.Backend == "" {
+		r.Spec.Backend = defaults.Backend
+	}
+}
+
+// GetInterval returns the probe interval, falling back to the built-in default if neither the spec nor the operator config set one
+func (r RouteMonitor) GetInterval() string {
+	if r.Spec.Interval == "" {
+		return blackbox.DefaultInterval
+	}
+	return r.Spec.Interval
+}
+
+// GetScrapeTimeout returns the probe timeout, falling back to the built-in default if neither the spec nor the operator config set one
+func (r RouteMonitor) GetScrapeTimeout() string {
+	if r.Spec.ScrapeTimeout == "" {
+		return blackbox.DefaultScrapeTimeout
+	}
+	return r.Spec.ScrapeTimeout
+}
+
+// ValidateProbeTimings verifies that the interval and timeout are durations and that the timeout is smaller than the interval.
+// An empty one is compared with its default, so a RouteMonitor that only sets one of them is checked against the other default
+func (r RouteMonitor) ValidateProbeTimings() error {
+	interval, err := time.ParseDuration(r.GetInterval())
+	if err != nil {
+		return fmt.Errorf("%w: cannot parse interval '%s'", customerrors.InvalidCR, r.GetInterval())
+	}
+	scrapeTimeout, err := time.ParseDuration(r.GetScrapeTimeout())
+	if err != nil {
+		return fmt.Errorf("%w: cannot parse scrapeTimeout '%s'", customerrors.InvalidCR, r.GetScrapeTimeout())
+	}
+	if scrapeTimeout >= interval {
+		return fmt.Errorf("%w: scrapeTimeout '%s' has to be smaller than interval '%s'", customerrors.InvalidCR, r.GetScrapeTimeout(), r.GetInterval())
+	}
+	return nil
+}
+
+// GetModule returns the blackbox exporter module used for probing, falling back to the built-in default if neither the spec nor the operator config set one
+func (r RouteMonitor) GetModule() string {
+	if r.Spec.Module == "" {
+		return blackbox.DefaultModule
+	}
+	return r.Spec.Module
+}
+
+// GetBackend returns the resource the probes are scraped with, falling back to the built-in default if neither the spec nor the operator config set one
+func (r RouteMonitor) GetBackend() string {
+	if r.Spec.Backend == "" {
+		return BackendServiceMonitor
+	}
+	return r.Spec.Backend
+}
+
+// TemplateForEffectiveProbe returns the probe settings in use once the defaults are applied, shown in the status as the stored spec keeps them empty
+func (r RouteMonitor) TemplateForEffectiveProbe() RouteMonitorEffectiveProbe {
+	return RouteMonitorEffectiveProbe{
+		Interval:      r.GetInterval(),
+		ScrapeTimeout: r.GetScrapeTimeout(),
+		Module:        r.GetModule(),
+		Backend:       r.GetBackend(),
+	}
+}
+
+// GetFor returns how long the probes have to fail before the alert fires, falling back to the default if none is set
+func (a RouteMonitorAlerting) GetFor() string {
+	if a.For == "" {
+		return blackbox.DefaultAlertFor
+	}
+	return a.For
+}
+
+// GetSeverity returns the severity of the alert, falling back to the default if none is set
+func (a RouteMonitorAlerting) GetSeverity() string {
+	if a.Severity == "" {
+		return blackbox.DefaultAlertSeverity
+	}
+	return a.Severity
+}
+
+// TemplateForModuleName returns the name of the module the RouteMonitor is probed with.
+// A RouteMonitor with HTTP settings gets its own module, named after the module it builds upon and the RouteMonitor
+func (r RouteMonitor) TemplateForModuleName() string {
+	if r.Spec.HTTP == nil {
+		return r.GetModule()
+	}
+	// namespaces and names cannot contain '_', so the generated name is unique
+	return fmt.Sprintf("%s_%s_%s", r.GetModule(), r.Namespace, r.Name)
+}
+
+// TemplateForModule returns the definition of the module the RouteMonitor is probed with
+func (r RouteMonitor) TemplateForModule() (blackboxconfig.Module, error) {
+	module, ok := blackboxconfig.Lookup(r.GetModule())
+	if !ok {
+		return blackboxconfig.Module{}, fmt.Errorf("%w: unknown module '%s', known modules are %v", customerrors.InvalidCR, r.GetModule(), blackboxconfig.KnownModules())
+	}
+
+	httpSpec := r.Spec.HTTP
+	if httpSpec == nil {
+		return module, nil
+	}
+	if module.Prober != "http" {
+		return blackboxconfig.Module{}, fmt.Errorf("%w: http settings cannot be used with the %s prober of module '%s'", customerrors.InvalidCR, module.Prober, r.GetModule())
+	}
+	for _, expression := range append(httpSpec.FailIfBodyMatchesRegexp, httpSpec.FailIfBodyNotMatchesRegexp...) {
+		if _, err := regexp.Compile(expression); err != nil {
+			return blackboxconfig.Module{}, fmt.Errorf("%w: cannot compile body regexp '%s'", customerrors.InvalidCR, expression)
+		}
+	}
+
+	// copy the settings of the known module, so it stays untouched
+	httpProbe := blackboxconfig.HTTPProbe{}
+	if module.HTTP != nil {
+		httpProbe = *module.HTTP
+	}
+	if httpSpec.Method != "" {
+		httpProbe.Method = httpSpec.Method
+	}
+	httpProbe.Headers = httpSpec.Headers
+	httpProbe.Body = httpSpec.Body
+	httpProbe.ValidStatusCodes = httpSpec.ValidStatusCodes
+	httpProbe.FailIfBodyMatchesRegexp = httpSpec.FailIfBodyMatchesRegexp
+	httpProbe.FailIfBodyNotMatchesRegexp = httpSpec.FailIfBodyNotMatchesRegexp
+	httpProbe.NoFollowRedirects = httpSpec.FollowRedirects != nil && !*httpSpec.FollowRedirects
+	module.HTTP = &httpProbe
+	return module, nil
+}
+
+// ValidateModule verifies that the blackbox exporter module can be configured by the operator
+func (r RouteMonitor) ValidateModule() error {
+	_, err := r.TemplateForModule()
+	return err
+}
+
+// SetCondition sets a condition on the status for the current generation of the RouteMonitor
+func (r *RouteMonitor) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	SetCondition(&r.Status.Conditions, Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: r.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// readinessConditions are the conditions that make up the Ready condition, in the order the reconcile checks them
+var readinessConditions = []string{
+	ConditionBlackboxExporterReady,
+	ConditionRouteFound,
+	ConditionURLResolved,
+	ConditionServiceMonitorReady,
+	ConditionAlertingReady,
+}
+
+// SetReadyCondition summarizes the other conditions into Ready,
+// taking over the reason and message of the first condition that isn't True
+func (r *RouteMonitor) SetReadyCondition() {
+	for _, conditionType := range readinessConditions {
+		condition := FindCondition(r.Status.Conditions, conditionType)
+		if condition == nil {
+			r.SetCondition(ConditionReady, metav1.ConditionFalse, ReasonWaitingForCondition, fmt.Sprintf("Waiting for condition %s", conditionType))
+			return
+		}
+		if condition.Status != metav1.ConditionTrue {
+			r.SetCondition(ConditionReady, metav1.ConditionFalse, condition.Reason, condition.Message)
+			return
+		}
+	}
+	r.SetCondition(ConditionReady, metav1.ConditionTrue, ReasonReady, "The Route is probed by the blackbox exporter")
+}
+
+// GetRouteNamespace returns the namespace of the Route, falling back to the namespace of the RouteMonitor if none is set
+func (r RouteMonitor) GetRouteNamespace() string {
+	if r.Spec.Route.Namespace == "" {
+		return r.Namespace
+	}
+	return r.Spec.Route.Namespace
+}
+
+// ValidateRoute verifies that the RouteMonitor either names a Route, selects Routes or has a URL
+func (r RouteMonitor) ValidateRoute() error {
+	if r.Spec.URL != "" {
+		if r.Spec.Route.Name != "" || r.Spec.RouteSelector != nil {
+			return fmt.Errorf("%w: url cannot be used together with route or routeSelector", customerrors.InvalidCR)
+		}
+		return r.ValidateURL()
+	}
+	if r.Spec.RouteSelector != nil {
+		if r.Spec.Route.Name != "" {
+			return fmt.Errorf("%w: route and routeSelector cannot be used together", customerrors.InvalidCR)
+		}
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.RouteSelector); err != nil {
+			return fmt.Errorf("%w: cannot parse routeSelector: %v", customerrors.InvalidCR, err)
+		}
+		return nil
+	}
+	if r.Spec.Route.Name == "" {
+		return fmt.Errorf("%w: Cannot retrieve route if its name is empty", customerrors.InvalidCR)
+	}
+	return nil
+}
+
+// ValidateURL verifies that the URL of the spec is an absolute http or https url
+func (r RouteMonitor) ValidateURL() error {
+	parsedURL, err := url.Parse(r.Spec.URL)
+	if err != nil {
+		return fmt.Errorf("%w: cannot parse url '%s'", customerrors.InvalidCR, r.Spec.URL)
+	}
+	if (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return fmt.Errorf("%w: url '%s' has to be an absolute http or https url", customerrors.InvalidCR, r.Spec.URL)
+	}
+	return nil
+}
+
+// GetRouteKey returns the namespace/name of the single Route the RouteMonitor references,
+// or an empty string when it selects Routes or probes a url
+func (r RouteMonitor) GetRouteKey() string {
+	if r.Spec.RouteSelector != nil || r.Spec.URL != "" || r.Spec.Route.Name == "" {
+		return ""
+	}
+	return types.NamespacedName{Namespace: r.GetRouteNamespace(), Name: r.Spec.Route.Name}.String()
+}
+
+// SelectsRoute verifies that the RouteSelector of the RouteMonitor matches a Route with the given namespace and labels
+func (r RouteMonitor) SelectsRoute(routeNamespace string, routeLabels map[string]string) bool {
+	if r.Spec.RouteSelector == nil || r.Namespace != routeNamespace {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(r.Spec.RouteSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(routeLabels))
+}
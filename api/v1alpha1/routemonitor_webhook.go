@@ -0,0 +1,152 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var routemonitorlog = logf.Log.WithName("routemonitor-resource")
+
+func (r *RouteMonitor) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-monitoring-openshift-io-v1alpha1-routemonitor,mutating=true,failurePolicy=fail,groups=monitoring.openshift.io,resources=routemonitors,verbs=create;update,versions=v1alpha1,name=mroutemonitor.kb.io
+
+var _ webhook.Defaulter = &RouteMonitor{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+// It fills in the namespace of the Route. The probe settings are left empty, so they follow the defaults of the operator
+// even when those change later; the settings in use are shown in status.effectiveProbe
+func (r *RouteMonitor) Default() {
+	routemonitorlog.V(1).Info("default", "namespace", r.Namespace, "name", r.Name)
+
+	if r.Spec.RouteSelector == nil && r.Spec.URL == "" {
+		r.Spec.Route.Namespace = r.GetRouteNamespace()
+	}
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-monitoring-openshift-io-v1alpha1-routemonitor,mutating=false,failurePolicy=fail,groups=monitoring.openshift.io,resources=routemonitors,versions=v1alpha1,name=vroutemonitor.kb.io
+
+var _ webhook.Validator = &RouteMonitor{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *RouteMonitor) ValidateCreate() error {
+	routemonitorlog.V(1).Info("validate create", "namespace", r.Namespace, "name", r.Name)
+	return r.toInvalidError(r.validateRouteMonitor())
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *RouteMonitor) ValidateUpdate(old runtime.Object) error {
+	routemonitorlog.V(1).Info("validate update", "namespace", r.Namespace, "name", r.Name)
+
+	// a RouteMonitor that is deleted has to be able to drop its finalizer, whatever its spec is
+	if r.WasDeleteRequested() {
+		return nil
+	}
+	// an update that leaves the spec as is, e.g. of the finalizers or the storage migration, is not rejected
+	// for a spec that was stored before the webhook validated it
+	if oldRouteMonitor, ok := old.(*RouteMonitor); ok && reflect.DeepEqual(r.Spec, oldRouteMonitor.Spec) {
+		return nil
+	}
+
+	allErrs := r.validateRouteMonitor()
+	if oldRouteMonitor, ok := old.(*RouteMonitor); ok {
+		// RouteMonitors stored before the defaulting webhook existed can lack the namespace of the Route
+		oldRouteMonitor = oldRouteMonitor.DeepCopy()
+		oldRouteMonitor.Default()
+		if !reflect.DeepEqual(r.Spec.Route, oldRouteMonitor.Spec.Route) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "route"), "is immutable, create a new RouteMonitor to monitor another Route"))
+		}
+	}
+	return r.toInvalidError(allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *RouteMonitor) ValidateDelete() error {
+	return nil
+}
+
+// validateRouteMonitor rejects the specs the reconcile would fail on with an "Invalid CR" error
+func (r *RouteMonitor) validateRouteMonitor() field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+
+	routePath := specPath.Child("route")
+	if r.Spec.URL != "" {
+		if r.Spec.Route.Name != "" || r.Spec.RouteSelector != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("url"), "cannot be used together with spec.route or spec.routeSelector"))
+		}
+		if err := r.ValidateURL(); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("url"), r.Spec.URL, withoutInvalidCRPrefix(err)))
+		}
+	} else if r.Spec.RouteSelector != nil {
+		if r.Spec.Route.Name != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("routeSelector"), "cannot be used together with spec.route"))
+		}
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.RouteSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("routeSelector"), r.Spec.RouteSelector, err.Error()))
+		}
+	} else {
+		if r.Spec.Route.Name == "" {
+			allErrs = append(allErrs, field.Required(routePath.Child("name"), "the Route to monitor has to be named, or selected via spec.routeSelector"))
+		}
+	}
+	if r.Spec.Route.Namespace != "" && r.Spec.Route.Namespace != r.Namespace {
+		allErrs = append(allErrs, field.Invalid(routePath.Child("namespace"), r.Spec.Route.Namespace, "the Route has to be in the namespace of the RouteMonitor"))
+	}
+
+	if err := r.ValidateProbeTimings(); err != nil {
+		// An interval that doesn't fit the default scrapeTimeout is the one to change
+		if _, parseErr := time.ParseDuration(r.GetInterval()); parseErr != nil || r.Spec.ScrapeTimeout == "" {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("interval"), r.Spec.Interval, withoutInvalidCRPrefix(err)))
+		} else {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("scrapeTimeout"), r.Spec.ScrapeTimeout, withoutInvalidCRPrefix(err)))
+		}
+	}
+	if err := r.ValidateModule(); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("module"), r.Spec.Module, withoutInvalidCRPrefix(err)))
+	}
+	return allErrs
+}
+
+// toInvalidError wraps the errors into the error the API server returns for invalid objects
+func (r *RouteMonitor) toInvalidError(allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("RouteMonitor").GroupKind(), r.Name, allErrs)
+}
+
+// withoutInvalidCRPrefix drops the prefix of the reconcile errors, as the API server already reports the object as invalid
+func withoutInvalidCRPrefix(err error) string {
+	return strings.TrimPrefix(err.Error(), "Invalid CR: ")
+}
@@ -0,0 +1,276 @@
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RouteMonitor Webhook", func() {
+	var (
+		routeMonitor v1alpha1.RouteMonitor
+		spec         v1alpha1.RouteMonitorSpec
+	)
+	BeforeEach(func() {
+		spec = v1alpha1.RouteMonitorSpec{
+			Route: v1alpha1.RouteMonitorRouteSpec{
+				Name:      "route",
+				Namespace: "namespace",
+			},
+		}
+	})
+	JustBeforeEach(func() {
+		routeMonitor = v1alpha1.RouteMonitor{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "routemonitor",
+				Namespace: "namespace",
+			},
+			Spec: spec,
+		}
+	})
+
+	Describe("Default", func() {
+		When("the settings are empty", func() {
+			BeforeEach(func() {
+				spec.Route.Namespace = ""
+			})
+			It("should fill in the namespace of the RouteMonitor and leave the probe settings to the defaults of the operator", func() {
+				// Act
+				routeMonitor.Default()
+				// Assert
+				Expect(routeMonitor.Spec.Route.Namespace).To(Equal("namespace"))
+				Expect(routeMonitor.Spec.Interval).To(BeEmpty())
+				Expect(routeMonitor.Spec.ScrapeTimeout).To(BeEmpty())
+				Expect(routeMonitor.Spec.Module).To(BeEmpty())
+			})
+		})
+		When("the settings are set", func() {
+			BeforeEach(func() {
+				spec.Interval = "1m"
+				spec.ScrapeTimeout = "10s"
+				spec.Module = "tcp_connect"
+			})
+			It("should keep them", func() {
+				// Act
+				routeMonitor.Default()
+				// Assert
+				Expect(routeMonitor.Spec.Interval).To(Equal("1m"))
+				Expect(routeMonitor.Spec.ScrapeTimeout).To(Equal("10s"))
+				Expect(routeMonitor.Spec.Module).To(Equal("tcp_connect"))
+			})
+		})
+		When("the RouteMonitor selects Routes", func() {
+			BeforeEach(func() {
+				spec.Route = v1alpha1.RouteMonitorRouteSpec{}
+				spec.RouteSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "public"}}
+			})
+			It("should leave the route empty", func() {
+				// Act
+				routeMonitor.Default()
+				// Assert
+				Expect(routeMonitor.Spec.Route).To(Equal(v1alpha1.RouteMonitorRouteSpec{}))
+			})
+		})
+	})
+
+	Describe("ValidateCreate", func() {
+		When("the spec is valid", func() {
+			It("should admit the RouteMonitor", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the route fields are empty", func() {
+			BeforeEach(func() {
+				spec.Route = v1alpha1.RouteMonitorRouteSpec{}
+			})
+			It("should reject the RouteMonitor", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.route.name"))
+			})
+		})
+		When("the Route is in another namespace", func() {
+			BeforeEach(func() {
+				spec.Route.Namespace = "other-namespace"
+			})
+			It("should reject the RouteMonitor", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.route.namespace"))
+			})
+		})
+		When("route and routeSelector are both set", func() {
+			BeforeEach(func() {
+				spec.RouteSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "public"}}
+			})
+			It("should reject the RouteMonitor", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.routeSelector"))
+			})
+		})
+		When("only the routeSelector is set", func() {
+			BeforeEach(func() {
+				spec.Route = v1alpha1.RouteMonitorRouteSpec{}
+				spec.RouteSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "public"}}
+			})
+			It("should admit the RouteMonitor", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the scrapeTimeout is not smaller than the interval", func() {
+			BeforeEach(func() {
+				spec.Interval = "10s"
+				spec.ScrapeTimeout = "30s"
+			})
+			It("should reject the RouteMonitor on the scrapeTimeout", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.scrapeTimeout"))
+				Expect(err.Error()).NotTo(ContainSubstring("Invalid CR"))
+			})
+		})
+		When("only the scrapeTimeout is set and it is not smaller than the default interval", func() {
+			BeforeEach(func() {
+				spec.ScrapeTimeout = "30s"
+			})
+			It("should reject the RouteMonitor on the scrapeTimeout", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.scrapeTimeout"))
+			})
+		})
+		When("only the interval is set and it is not bigger than the default scrapeTimeout", func() {
+			BeforeEach(func() {
+				spec.Interval = "10s"
+			})
+			It("should reject the RouteMonitor on the interval", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.interval"))
+			})
+		})
+		When("the interval cannot be parsed", func() {
+			BeforeEach(func() {
+				spec.Interval = "often"
+			})
+			It("should reject the RouteMonitor on the interval", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.interval"))
+			})
+		})
+		When("the module is unknown", func() {
+			BeforeEach(func() {
+				spec.Module = "unknown"
+			})
+			It("should reject the RouteMonitor", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.module"))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var oldRouteMonitor *v1alpha1.RouteMonitor
+		JustBeforeEach(func() {
+			oldRouteMonitor = routeMonitor.DeepCopy()
+		})
+		When("the probe settings change", func() {
+			It("should admit the RouteMonitor", func() {
+				// Arrange
+				routeMonitor.Spec.Interval = "1m"
+				// Act
+				err := routeMonitor.ValidateUpdate(oldRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the route changes", func() {
+			It("should reject the RouteMonitor as the route is immutable", func() {
+				// Arrange
+				routeMonitor.Spec.Route.Name = "other-route"
+				// Act
+				err := routeMonitor.ValidateUpdate(oldRouteMonitor)
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("immutable"))
+			})
+		})
+		When("the stored RouteMonitor lacks the namespace of the Route", func() {
+			It("should admit the defaulted RouteMonitor", func() {
+				// Arrange
+				oldRouteMonitor.Spec.Route.Namespace = ""
+				routeMonitor.Default()
+				// Act
+				err := routeMonitor.ValidateUpdate(oldRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("a stored invalid RouteMonitor is written without changing its spec", func() {
+			It("should admit the update, so it can be migrated to the storage version", func() {
+				// Arrange
+				oldRouteMonitor.Spec.Module = "unknown"
+				routeMonitor.Spec.Module = "unknown"
+				routeMonitor.Finalizers = []string{"finalizer"}
+				// Act
+				err := routeMonitor.ValidateUpdate(oldRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the spec of a stored invalid RouteMonitor changes", func() {
+			It("should reject the RouteMonitor", func() {
+				// Arrange
+				oldRouteMonitor.Spec.Module = "unknown"
+				routeMonitor.Spec.Module = "unknown"
+				routeMonitor.Spec.Interval = "1m"
+				// Act
+				err := routeMonitor.ValidateUpdate(oldRouteMonitor)
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.module"))
+			})
+		})
+		When("an invalid RouteMonitor is deleted", func() {
+			It("should admit the update, so the finalizer can be removed", func() {
+				// Arrange
+				oldRouteMonitor.Spec.Module = "unknown"
+				routeMonitor.Spec.Module = "unknown"
+				routeMonitor.Finalizers = []string{}
+				routeMonitor.DeletionTimestamp = &metav1.Time{}
+				// Act
+				err := routeMonitor.ValidateUpdate(oldRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
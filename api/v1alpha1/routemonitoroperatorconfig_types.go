@@ -0,0 +1,150 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperatorConfigName is the name of the only RouteMonitorOperatorConfig the operator reads
+const OperatorConfigName = "cluster"
+
+// RouteMonitorOperatorConfigSpec defines the settings that apply to every RouteMonitor and the resources they share
+type RouteMonitorOperatorConfigSpec struct {
+	// BlackBoxExporter configures the deployment of the blackbox exporter
+	// +optional
+	BlackBoxExporter BlackBoxExporterConfig `json:"blackBoxExporter,omitempty"`
+
+	// ProbeDefaults are used by the RouteMonitors that don't set their own probe settings
+	// +optional
+	ProbeDefaults ProbeDefaults `json:"probeDefaults,omitempty"`
+
+	// ServiceMonitor configures the generated ServiceMonitors
+	// +optional
+	ServiceMonitor ServiceMonitorConfig `json:"serviceMonitor,omitempty"`
+}
+
+// BlackBoxExporterConfig configures the deployment of the blackbox exporter
+type BlackBoxExporterConfig struct {
+	// Image is the image of the blackbox exporter, it overrides the image the operator was started with
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ImagePullSecrets are used to pull the image of the blackbox exporter, e.g. from a private mirror.
+	// The secrets have to exist in the namespace of the exporter
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Replicas is the number of exporter pods, defaults to 2
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources are the resource requests and limits of the exporter container
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector constrains the nodes the exporter pods are scheduled on
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allow the exporter pods to be scheduled on tainted nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// KubeRBACProxy fronts the exporter with kube-rbac-proxy, so only authorized clients can use it to probe
+	// +optional
+	KubeRBACProxy KubeRBACProxyConfig `json:"kubeRBACProxy,omitempty"`
+
+	// PrometheusNamespaceSelector selects the namespaces whose Prometheus pods may reach the exporter.
+	// Defaults to the namespaces of the Cluster Monitoring Operator, labelled openshift.io/cluster-monitoring=true
+	// +optional
+	PrometheusNamespaceSelector *metav1.LabelSelector `json:"prometheusNamespaceSelector,omitempty"`
+}
+
+// KubeRBACProxyConfig configures the kube-rbac-proxy sidecar of the blackbox exporter
+type KubeRBACProxyConfig struct {
+	// Enabled serves the exporter with TLS and requires the clients to be authorized to get the /probe url
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Image is the image of kube-rbac-proxy, it overrides the image the operator was started with
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// CAFile is the path of the CA that signed the certificate of the exporter, as seen by Prometheus.
+	// Defaults to the service CA bundle of the Cluster Monitoring Operator
+	// +optional
+	CAFile string `json:"caFile,omitempty"`
+}
+
+// ProbeDefaults are the probe settings of the RouteMonitors that don't set their own
+type ProbeDefaults struct {
+	// Interval is how often the targets are probed, defaults to 30s
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// ScrapeTimeout is how long a probe may take before it fails, defaults to 15s.
+	// It has to be smaller than the Interval
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+
+	// Module is the blackbox exporter module the targets are probed with, defaults to http_2xx
+	// +optional
+	Module string `json:"module,omitempty"`
+
+	// Backend is the Prometheus Operator resource the probes are scraped with, defaults to ServiceMonitor
+	// +kubebuilder:validation:Enum=ServiceMonitor;Probe
+	// +optional
+	Backend string `json:"backend,omitempty"`
+}
+
+// ServiceMonitorConfig configures the generated ServiceMonitors
+type ServiceMonitorConfig struct {
+	// Labels are added to every generated ServiceMonitor, e.g. to match the serviceMonitorSelector of a Prometheus
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RouteMonitorOperatorConfig is the Schema for the routemonitoroperatorconfigs API.
+// Only the one named cluster is read by the operator
+type RouteMonitorOperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RouteMonitorOperatorConfigSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouteMonitorOperatorConfigList contains a list of RouteMonitorOperatorConfig
+type RouteMonitorOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouteMonitorOperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RouteMonitorOperatorConfig{}, &RouteMonitorOperatorConfigList{})
+}
@@ -0,0 +1,33 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openshift/route-monitor-operator/pkg/const/blackbox"
+)
+
+// Validate verifies the probe defaults the same way the settings of a RouteMonitor are verified.
+// An empty setting falls back to its built-in default, which has to fit the configured ones
+func (d ProbeDefaults) Validate() error {
+	routeMonitor := RouteMonitor{Spec: RouteMonitorSpec{
+		Interval:      blackbox.DefaultInterval,
+		ScrapeTimeout: blackbox.DefaultScrapeTimeout,
+		Module:        blackbox.DefaultModule,
+	}}
+	if d.Interval != "" {
+		routeMonitor.Spec.Interval = d.Interval
+	}
+	if d.ScrapeTimeout != "" {
+		routeMonitor.Spec.ScrapeTimeout = d.ScrapeTimeout
+	}
+	if d.Module != "" {
+		routeMonitor.Spec.Module = d.Module
+	}
+	for _, err := range []error{routeMonitor.ValidateProbeTimings(), routeMonitor.ValidateModule()} {
+		if err != nil {
+			return fmt.Errorf("Invalid RouteMonitorOperatorConfig: %s", strings.TrimPrefix(err.Error(), "Invalid CR: "))
+		}
+	}
+	return nil
+}
@@ -1,12 +1,15 @@
 package v1alpha1_test
 
 import (
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
+	"github.com/openshift/route-monitor-operator/pkg/const/blackbox"
+	"github.com/openshift/route-monitor-operator/pkg/util/blackboxconfig"
 
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -79,7 +82,7 @@ var _ = Describe("V1alpha1", func() {
 	})
 	Describe("TemplateForServiceMonitorName", func() {
 		When("names are set", func() {
-			It("should return a combined name", func() {
+			It("should return a combined name suffixed by a hash", func() {
 				// Arrange
 				routeMonitor.Name = "olf"
 				routeMonitor.Namespace = "dolf"
@@ -87,10 +90,713 @@ var _ = Describe("V1alpha1", func() {
 				// Act
 				res := routeMonitor.TemplateForServiceMonitorName()
 				// Assert
-				Expect(res).To(Equal(types.NamespacedName{
-					Name:      "olf-dolf",
-					Namespace: "openshift-monitoring",
+				Expect(res.Namespace).To(Equal("openshift-monitoring"))
+				Expect(res.Name).To(MatchRegexp(`^olf-dolf-[0-9a-f]{10}$`))
+			})
+		})
+		When("joining the names is ambiguous", func() {
+			It("should return different names", func() {
+				// Arrange
+				other := routeMonitor.DeepCopy()
+				routeMonitor.Name, routeMonitor.Namespace = "a-b", "c"
+				other.Name, other.Namespace = "a", "b-c"
+
+				// Act
+				res := routeMonitor.TemplateForServiceMonitorName()
+				otherRes := other.TemplateForServiceMonitorName()
+				// Assert
+				Expect(res.Name).To(HavePrefix("a-b-c-"))
+				Expect(otherRes.Name).To(HavePrefix("a-b-c-"))
+				Expect(res.Name).NotTo(Equal(otherRes.Name))
+			})
+		})
+		When("the names are long", func() {
+			It("should truncate the name to fit a label value and keep it unique", func() {
+				// Arrange
+				routeMonitor.Name = strings.Repeat("n", 100)
+				routeMonitor.Namespace = strings.Repeat("s", 60)
+				other := routeMonitor.DeepCopy()
+				other.Name = strings.Repeat("n", 101)
+
+				// Act
+				res := routeMonitor.TemplateForServiceMonitorName()
+				otherRes := other.TemplateForServiceMonitorName()
+				// Assert
+				Expect(len(res.Name)).To(BeNumerically("<=", 63))
+				Expect(res.Name).NotTo(Equal(otherRes.Name))
+				Expect(res).To(Equal(routeMonitor.TemplateForServiceMonitorName()))
+			})
+		})
+	})
+	Describe("TemplateForServiceMonitorNamespace", func() {
+		JustBeforeEach(func() {
+			routeMonitor.Namespace = "dolf"
+		})
+		When("the ServiceMonitors are placed next to the exporter", func() {
+			It("should return the namespace of the exporter", func() {
+				// Act
+				res := routeMonitor.TemplateForServiceMonitorNamespace()
+				// Assert
+				Expect(res).To(Equal(blackbox.BlackBoxNamespace))
+			})
+		})
+		When("the ServiceMonitors are placed next to the RouteMonitors", func() {
+			BeforeEach(func() {
+				Expect(blackbox.Configure("blackbox", blackbox.ServiceMonitorInRouteMonitorNamespace)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(blackbox.Configure(blackbox.DefaultBlackBoxNamespace, blackbox.ServiceMonitorInExporterNamespace)).To(Succeed())
+			})
+			It("should return the namespace of the RouteMonitor", func() {
+				// Act
+				res := routeMonitor.TemplateForServiceMonitorNamespace()
+				// Assert
+				Expect(res).To(Equal("dolf"))
+			})
+		})
+		When("the placement is unknown", func() {
+			It("should be rejected", func() {
+				// Act
+				err := blackbox.Configure(blackbox.DefaultBlackBoxNamespace, "elsewhere")
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(blackbox.ServiceMonitorPlacement).To(Equal(blackbox.ServiceMonitorInExporterNamespace))
+			})
+		})
+	})
+	Describe("OwnsGeneratedResource", func() {
+		JustBeforeEach(func() {
+			routeMonitor.UID = "uid"
+		})
+		When("the ServiceMonitor carries the UID of the RouteMonitor", func() {
+			It("should return true", func() {
+				// Act
+				res := routeMonitor.OwnsGeneratedResource(&metav1.ObjectMeta{Labels: routeMonitor.TemplateForServiceMonitorOwnerLabels()})
+				// Assert
+				Expect(res).To(BeTrue())
+			})
+		})
+		When("the ServiceMonitor carries the UID of another RouteMonitor", func() {
+			It("should return false", func() {
+				// Act
+				res := routeMonitor.OwnsGeneratedResource(&metav1.ObjectMeta{Labels: map[string]string{routemonitorconst.ServiceMonitorOwnerUIDLabel: "other"}})
+				// Assert
+				Expect(res).To(BeFalse())
+			})
+		})
+		When("the ServiceMonitor has no owner", func() {
+			It("should return false", func() {
+				// Act
+				res := routeMonitor.OwnsGeneratedResource(&metav1.ObjectMeta{})
+				// Assert
+				Expect(res).To(BeFalse())
+			})
+		})
+	})
+	Describe("RouteMonitorOfServiceMonitor", func() {
+		When("the ServiceMonitor was generated for a RouteMonitor", func() {
+			It("should return the RouteMonitor", func() {
+				// Arrange
+				routeMonitor.Name = "olf"
+				routeMonitor.Namespace = "dolf"
+				serviceMonitor := metav1.ObjectMeta{
+					Labels:      routeMonitor.TemplateForServiceMonitorOwnerLabels(),
+					Annotations: routeMonitor.TemplateForServiceMonitorOwnerAnnotations(),
+				}
+				// Act
+				res, ok := v1alpha1.RouteMonitorOfServiceMonitor(&serviceMonitor)
+				// Assert
+				Expect(ok).To(BeTrue())
+				Expect(res).To(Equal(types.NamespacedName{Name: "olf", Namespace: "dolf"}))
+			})
+		})
+		When("the ServiceMonitor was not generated by the operator", func() {
+			It("should return false", func() {
+				// Arrange
+				serviceMonitor := metav1.ObjectMeta{Annotations: map[string]string{
+					routemonitorconst.ServiceMonitorOwnerNamespaceAnnotation: "dolf",
+					routemonitorconst.ServiceMonitorOwnerNameAnnotation:      "olf",
+				}}
+				// Act
+				_, ok := v1alpha1.RouteMonitorOfServiceMonitor(&serviceMonitor)
+				// Assert
+				Expect(ok).To(BeFalse())
+			})
+		})
+	})
+	Describe("GetInterval", func() {
+		When("no interval is set", func() {
+			It("should return the default", func() {
+				// Act
+				res := routeMonitor.GetInterval()
+				// Assert
+				Expect(res).To(Equal(blackbox.DefaultInterval))
+			})
+		})
+		When("no interval is set but the operator config sets a default", func() {
+			It("should return the default of the operator config once it is applied", func() {
+				// Arrange
+				routeMonitor.ApplyProbeDefaults(v1alpha1.ProbeDefaults{Interval: "1m"})
+				// Act
+				res := routeMonitor.GetInterval()
+				// Assert
+				Expect(res).To(Equal("1m"))
+			})
+		})
+		When("an interval is set", func() {
+			It("should return the interval", func() {
+				// Arrange
+				routeMonitor.Spec.Interval = "10s"
+				// Act
+				res := routeMonitor.GetInterval()
+				// Assert
+				Expect(res).To(Equal("10s"))
+			})
+		})
+	})
+	Describe("ProbeDefaults.Validate", func() {
+		When("no probe defaults are set", func() {
+			It("should accept the built-in defaults", func() {
+				// Act
+				err := v1alpha1.ProbeDefaults{}.Validate()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the interval is smaller than the built-in scrapeTimeout", func() {
+			It("should return an error", func() {
+				// Act
+				err := v1alpha1.ProbeDefaults{Interval: "10s"}.Validate()
+				// Assert
+				Expect(err).To(MatchError(ContainSubstring("Invalid RouteMonitorOperatorConfig")))
+			})
+		})
+		When("the module is unknown", func() {
+			It("should return an error", func() {
+				// Act
+				err := v1alpha1.ProbeDefaults{Module: "unknown"}.Validate()
+				// Assert
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+	Describe("GetScrapeTimeout", func() {
+		When("no scrapeTimeout is set", func() {
+			It("should return the default", func() {
+				// Act
+				res := routeMonitor.GetScrapeTimeout()
+				// Assert
+				Expect(res).To(Equal(blackbox.DefaultScrapeTimeout))
+			})
+		})
+		When("a scrapeTimeout is set", func() {
+			It("should return the scrapeTimeout", func() {
+				// Arrange
+				routeMonitor.Spec.ScrapeTimeout = "5s"
+				// Act
+				res := routeMonitor.GetScrapeTimeout()
+				// Assert
+				Expect(res).To(Equal("5s"))
+			})
+		})
+	})
+	Describe("GetModule", func() {
+		When("no module is set", func() {
+			It("should return the default", func() {
+				// Act
+				res := routeMonitor.GetModule()
+				// Assert
+				Expect(res).To(Equal(blackbox.DefaultModule))
+			})
+		})
+		When("a module is set", func() {
+			It("should return the module", func() {
+				// Arrange
+				routeMonitor.Spec.Module = "tcp_connect"
+				// Act
+				res := routeMonitor.GetModule()
+				// Assert
+				Expect(res).To(Equal("tcp_connect"))
+			})
+		})
+	})
+	Describe("GetBackend", func() {
+		When("no backend is set", func() {
+			It("should return the ServiceMonitor backend", func() {
+				// Act
+				res := routeMonitor.GetBackend()
+				// Assert
+				Expect(res).To(Equal(v1alpha1.BackendServiceMonitor))
+			})
+		})
+		When("no backend is set but the operator config sets a default", func() {
+			It("should return the default of the operator config once it is applied", func() {
+				// Arrange
+				routeMonitor.ApplyProbeDefaults(v1alpha1.ProbeDefaults{Backend: v1alpha1.BackendProbe})
+				// Act
+				res := routeMonitor.GetBackend()
+				// Assert
+				Expect(res).To(Equal(v1alpha1.BackendProbe))
+			})
+			It("should return the backend of the RouteMonitor when it is set", func() {
+				// Arrange
+				routeMonitor.Spec.Backend = v1alpha1.BackendServiceMonitor
+				routeMonitor.ApplyProbeDefaults(v1alpha1.ProbeDefaults{Backend: v1alpha1.BackendProbe})
+				// Act
+				res := routeMonitor.GetBackend()
+				// Assert
+				Expect(res).To(Equal(v1alpha1.BackendServiceMonitor))
+			})
+		})
+	})
+	Describe("RouteMonitorAlerting", func() {
+		When("nothing is set", func() {
+			It("should return the defaults", func() {
+				// Arrange
+				alerting := v1alpha1.RouteMonitorAlerting{}
+				// Act
+				forRes, severityRes := alerting.GetFor(), alerting.GetSeverity()
+				// Assert
+				Expect(forRes).To(Equal(blackbox.DefaultAlertFor))
+				Expect(severityRes).To(Equal(blackbox.DefaultAlertSeverity))
+			})
+		})
+		When("the duration and severity are set", func() {
+			It("should return them", func() {
+				// Arrange
+				alerting := v1alpha1.RouteMonitorAlerting{For: "15m", Severity: "critical"}
+				// Act
+				forRes, severityRes := alerting.GetFor(), alerting.GetSeverity()
+				// Assert
+				Expect(forRes).To(Equal("15m"))
+				Expect(severityRes).To(Equal("critical"))
+			})
+		})
+	})
+	Describe("TemplateForModuleName", func() {
+		BeforeEach(func() {
+			routeMonitorFinalizers = nil
+		})
+		JustBeforeEach(func() {
+			routeMonitor.Name = "olf"
+			routeMonitor.Namespace = "dolf"
+		})
+		When("no http settings are set", func() {
+			It("should return the chosen module", func() {
+				// Act
+				res := routeMonitor.TemplateForModuleName()
+				// Assert
+				Expect(res).To(Equal(blackbox.DefaultModule))
+			})
+		})
+		When("http settings are set", func() {
+			It("should return a name unique to the RouteMonitor", func() {
+				// Arrange
+				routeMonitor.Spec.HTTP = &v1alpha1.RouteMonitorHTTPSpec{}
+				// Act
+				res := routeMonitor.TemplateForModuleName()
+				// Assert
+				Expect(res).To(Equal("http_2xx_dolf_olf"))
+			})
+		})
+	})
+	Describe("TemplateForModule", func() {
+		When("no http settings are set", func() {
+			It("should return the known module", func() {
+				// Arrange
+				routeMonitor.Spec.Module = "tcp_connect"
+				// Act
+				res, err := routeMonitor.TemplateForModule()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(blackboxconfig.Module{Prober: "tcp"}))
+			})
+		})
+		When("http settings are set", func() {
+			It("should apply them on top of the chosen module", func() {
+				// Arrange
+				followRedirects := false
+				routeMonitor.Spec.Module = "http_post_2xx"
+				routeMonitor.Spec.HTTP = &v1alpha1.RouteMonitorHTTPSpec{
+					Headers:                 map[string]string{"Accept": "application/json"},
+					Body:                    "{}",
+					ValidStatusCodes:        []int{200, 204},
+					FailIfBodyMatchesRegexp: []string{"(?i)error"},
+					FollowRedirects:         &followRedirects,
+				}
+				// Act
+				res, err := routeMonitor.TemplateForModule()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(blackboxconfig.Module{
+					Prober: "http",
+					HTTP: &blackboxconfig.HTTPProbe{
+						Method:                  "POST",
+						Headers:                 map[string]string{"Accept": "application/json"},
+						Body:                    "{}",
+						ValidStatusCodes:        []int{200, 204},
+						FailIfBodyMatchesRegexp: []string{"(?i)error"},
+						NoFollowRedirects:       true,
+					},
 				}))
+				knownModule, _ := blackboxconfig.Lookup("http_post_2xx")
+				Expect(knownModule.HTTP).To(Equal(&blackboxconfig.HTTPProbe{Method: "POST"}))
+			})
+		})
+		When("http settings are set on a module that is not http", func() {
+			It("should return an error", func() {
+				// Arrange
+				routeMonitor.Spec.Module = "tcp_connect"
+				routeMonitor.Spec.HTTP = &v1alpha1.RouteMonitorHTTPSpec{}
+				// Act
+				_, err := routeMonitor.TemplateForModule()
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+		When("a body regexp does not compile", func() {
+			It("should return an error", func() {
+				// Arrange
+				routeMonitor.Spec.HTTP = &v1alpha1.RouteMonitorHTTPSpec{
+					FailIfBodyNotMatchesRegexp: []string{"("},
+				}
+				// Act
+				_, err := routeMonitor.TemplateForModule()
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+	})
+	Describe("ValidateModule", func() {
+		When("the module is known", func() {
+			It("should succeed", func() {
+				// Arrange
+				routeMonitor.Spec.Module = "http_post_2xx"
+				// Act
+				err := routeMonitor.ValidateModule()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the module is unknown", func() {
+			It("should return an error", func() {
+				// Arrange
+				routeMonitor.Spec.Module = "not_a_module"
+				// Act
+				err := routeMonitor.ValidateModule()
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+	})
+	Describe("ValidateProbeTimings", func() {
+		When("nothing is set", func() {
+			It("should accept the defaults", func() {
+				// Act
+				err := routeMonitor.ValidateProbeTimings()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the scrapeTimeout is smaller than the interval", func() {
+			It("should succeed", func() {
+				// Arrange
+				routeMonitor.Spec.Interval = "5m"
+				routeMonitor.Spec.ScrapeTimeout = "30s"
+				// Act
+				err := routeMonitor.ValidateProbeTimings()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the scrapeTimeout is not smaller than the interval", func() {
+			It("should return an error", func() {
+				// Arrange
+				routeMonitor.Spec.Interval = "10s"
+				// Act
+				err := routeMonitor.ValidateProbeTimings()
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+		When("only the interval is set and the operator config sets a smaller scrapeTimeout", func() {
+			It("should succeed", func() {
+				// Arrange
+				routeMonitor.Spec.Interval = "10s"
+				routeMonitor.ApplyProbeDefaults(v1alpha1.ProbeDefaults{ScrapeTimeout: "5s"})
+				// Act
+				err := routeMonitor.ValidateProbeTimings()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the interval is not a duration", func() {
+			It("should return an error", func() {
+				// Arrange
+				routeMonitor.Spec.Interval = "often"
+				// Act
+				err := routeMonitor.ValidateProbeTimings()
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+		When("the scrapeTimeout is not a duration", func() {
+			It("should return an error", func() {
+				// Arrange
+				routeMonitor.Spec.ScrapeTimeout = "quick"
+				// Act
+				err := routeMonitor.ValidateProbeTimings()
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+	})
+	Describe("SetCondition", func() {
+		When("the condition was never set", func() {
+			It("should add it with a transition time", func() {
+				// Arrange
+				routeMonitor.Generation = 3
+				// Act
+				routeMonitor.SetCondition(v1alpha1.ConditionRouteFound, metav1.ConditionTrue, v1alpha1.ReasonRouteFound, "found")
+				// Assert
+				condition := v1alpha1.FindCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionRouteFound)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.ObservedGeneration).To(Equal(int64(3)))
+				Expect(condition.LastTransitionTime.IsZero()).To(BeFalse())
+			})
+		})
+		When("the status of the condition does not change", func() {
+			It("should keep the transition time but take the new message", func() {
+				// Arrange
+				transitionTime := metav1.NewTime(time.Unix(0, 0))
+				routeMonitor.Status.Conditions = []v1alpha1.Condition{{
+					Type:               v1alpha1.ConditionRouteFound,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: transitionTime,
+					Reason:             v1alpha1.ReasonRouteFound,
+					Message:            "found",
+				}}
+				// Act
+				routeMonitor.SetCondition(v1alpha1.ConditionRouteFound, metav1.ConditionTrue, v1alpha1.ReasonRouteFound, "found again")
+				// Assert
+				Expect(routeMonitor.Status.Conditions).To(HaveLen(1))
+				Expect(routeMonitor.Status.Conditions[0].LastTransitionTime).To(Equal(transitionTime))
+				Expect(routeMonitor.Status.Conditions[0].Message).To(Equal("found again"))
+			})
+		})
+		When("the status of the condition changes", func() {
+			It("should move the transition time", func() {
+				// Arrange
+				transitionTime := metav1.NewTime(time.Unix(0, 0))
+				routeMonitor.Status.Conditions = []v1alpha1.Condition{{
+					Type:               v1alpha1.ConditionRouteFound,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: transitionTime,
+				}}
+				// Act
+				routeMonitor.SetCondition(v1alpha1.ConditionRouteFound, metav1.ConditionFalse, v1alpha1.ReasonRouteNotFound, "not found")
+				// Assert
+				Expect(routeMonitor.Status.Conditions).To(HaveLen(1))
+				Expect(routeMonitor.Status.Conditions[0].Status).To(Equal(metav1.ConditionFalse))
+				Expect(routeMonitor.Status.Conditions[0].LastTransitionTime).NotTo(Equal(transitionTime))
+			})
+		})
+	})
+	Describe("SetReadyCondition", func() {
+		When("all conditions are True", func() {
+			It("should set Ready to True", func() {
+				// Arrange
+				routeMonitor.SetCondition(v1alpha1.ConditionBlackboxExporterReady, metav1.ConditionTrue, v1alpha1.ReasonResourcesExist, "")
+				routeMonitor.SetCondition(v1alpha1.ConditionRouteFound, metav1.ConditionTrue, v1alpha1.ReasonRouteFound, "")
+				routeMonitor.SetCondition(v1alpha1.ConditionURLResolved, metav1.ConditionTrue, v1alpha1.ReasonURLResolved, "")
+				routeMonitor.SetCondition(v1alpha1.ConditionServiceMonitorReady, metav1.ConditionTrue, v1alpha1.ReasonResourcesExist, "")
+				routeMonitor.SetCondition(v1alpha1.ConditionAlertingReady, metav1.ConditionTrue, v1alpha1.ReasonAlertingDisabled, "")
+				// Act
+				routeMonitor.SetReadyCondition()
+				// Assert
+				Expect(v1alpha1.IsConditionTrue(routeMonitor.Status.Conditions, v1alpha1.ConditionReady)).To(BeTrue())
+			})
+		})
+		When("a condition is False", func() {
+			It("should take over its reason and message", func() {
+				// Arrange
+				routeMonitor.SetCondition(v1alpha1.ConditionBlackboxExporterReady, metav1.ConditionTrue, v1alpha1.ReasonResourcesExist, "")
+				routeMonitor.SetCondition(v1alpha1.ConditionRouteFound, metav1.ConditionFalse, v1alpha1.ReasonRouteNotFound, "route not found")
+				// Act
+				routeMonitor.SetReadyCondition()
+				// Assert
+				condition := v1alpha1.FindCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionReady)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(v1alpha1.ReasonRouteNotFound))
+				Expect(condition.Message).To(Equal("route not found"))
+			})
+		})
+		When("a condition was not set yet", func() {
+			It("should wait for it", func() {
+				// Act
+				routeMonitor.SetReadyCondition()
+				// Assert
+				condition := v1alpha1.FindCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionReady)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(v1alpha1.ReasonWaitingForCondition))
+			})
+		})
+	})
+	Describe("ValidateRoute", func() {
+		When("the Route is named", func() {
+			It("should succeed", func() {
+				// Arrange
+				routeMonitor.Spec.Route = v1alpha1.RouteMonitorRouteSpec{Name: "route", Namespace: "namespace"}
+				// Act
+				err := routeMonitor.ValidateRoute()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("neither a Route nor a RouteSelector is set", func() {
+			It("should return an Invalid CR error", func() {
+				// Act
+				err := routeMonitor.ValidateRoute()
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+		When("a url is set", func() {
+			It("should succeed", func() {
+				// Arrange
+				routeMonitor.Spec.URL = "https://example.com/status"
+				// Act
+				err := routeMonitor.ValidateRoute()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("a url is set together with a Route", func() {
+			It("should return an Invalid CR error", func() {
+				// Arrange
+				routeMonitor.Spec.URL = "https://example.com/status"
+				routeMonitor.Spec.Route = v1alpha1.RouteMonitorRouteSpec{Name: "route"}
+				// Act
+				err := routeMonitor.ValidateRoute()
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+		When("the url is not an http url", func() {
+			It("should return an Invalid CR error", func() {
+				// Arrange
+				routeMonitor.Spec.URL = "ftp://example.com/status"
+				// Act
+				err := routeMonitor.ValidateRoute()
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+		When("the RouteSelector cannot be parsed", func() {
+			It("should return an Invalid CR error", func() {
+				// Arrange
+				routeMonitor.Spec.RouteSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Near"}},
+				}
+				// Act
+				err := routeMonitor.ValidateRoute()
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+	})
+	Describe("GetRouteKey", func() {
+		JustBeforeEach(func() {
+			// Arrange
+			routeMonitor.Namespace = "namespace"
+			routeMonitor.Spec.Route = v1alpha1.RouteMonitorRouteSpec{Name: "route"}
+		})
+		When("the RouteMonitor references a Route", func() {
+			It("should return the namespace/name of the Route", func() {
+				// Act
+				res := routeMonitor.GetRouteKey()
+				// Assert
+				Expect(res).To(Equal("namespace/route"))
+			})
+		})
+		When("the RouteMonitor selects Routes", func() {
+			It("should return an empty key", func() {
+				// Arrange
+				routeMonitor.Spec.Route = v1alpha1.RouteMonitorRouteSpec{}
+				routeMonitor.Spec.RouteSelector = &metav1.LabelSelector{}
+				// Act
+				res := routeMonitor.GetRouteKey()
+				// Assert
+				Expect(res).To(BeEmpty())
+			})
+		})
+	})
+	Describe("SelectsRoute", func() {
+		JustBeforeEach(func() {
+			// Arrange
+			routeMonitor.Namespace = "namespace"
+			routeMonitor.Spec.RouteSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "public"}}
+		})
+		When("a Route in the namespace matches the RouteSelector", func() {
+			It("should return true", func() {
+				// Act
+				res := routeMonitor.SelectsRoute("namespace", map[string]string{"tier": "public", "app": "a"})
+				// Assert
+				Expect(res).To(BeTrue())
+			})
+		})
+		When("the Route is in another namespace", func() {
+			It("should return false", func() {
+				// Act
+				res := routeMonitor.SelectsRoute("other-namespace", map[string]string{"tier": "public"})
+				// Assert
+				Expect(res).To(BeFalse())
+			})
+		})
+		When("the labels of the Route do not match", func() {
+			It("should return false", func() {
+				// Act
+				res := routeMonitor.SelectsRoute("namespace", map[string]string{"tier": "private"})
+				// Assert
+				Expect(res).To(BeFalse())
+			})
+		})
+	})
+	Describe("ClusterRouteMonitor TemplateForRouteMonitor", func() {
+		When("a RouteMonitor is templated for a namespace", func() {
+			It("should select the Routes of the namespace with the probe settings of the ClusterRouteMonitor", func() {
+				// Arrange
+				clusterRouteMonitor := v1alpha1.ClusterRouteMonitor{
+					ObjectMeta: metav1.ObjectMeta{Name: "public"},
+					Spec: v1alpha1.ClusterRouteMonitorSpec{
+						RouteSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "public"}},
+						Interval:      "1m",
+						Module:        "tcp_connect",
+					},
+				}
+				// Act
+				res := clusterRouteMonitor.TemplateForRouteMonitor("tenant")
+				// Assert
+				Expect(res.Name).To(Equal("cluster-public"))
+				Expect(res.Namespace).To(Equal("tenant"))
+				Expect(res.Labels).To(HaveKeyWithValue(routemonitorconst.ClusterRouteMonitorLabel, "public"))
+				Expect(res.Spec.RouteSelector).To(Equal(&clusterRouteMonitor.Spec.RouteSelector))
+				Expect(res.Spec.Interval).To(Equal("1m"))
+				Expect(res.Spec.Module).To(Equal("tcp_connect"))
+				Expect(res.SelectsRoute("tenant", map[string]string{"tier": "public"})).To(BeTrue())
 			})
 		})
 	})
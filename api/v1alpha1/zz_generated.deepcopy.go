@@ -21,16 +21,226 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackBoxExporterConfig) DeepCopyInto(out *BlackBoxExporterConfig) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.KubeRBACProxy = in.KubeRBACProxy
+	if in.PrometheusNamespaceSelector != nil {
+		in, out := &in.PrometheusNamespaceSelector, &out.PrometheusNamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackBoxExporterConfig.
+func (in *BlackBoxExporterConfig) DeepCopy() *BlackBoxExporterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackBoxExporterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRouteMonitor) DeepCopyInto(out *ClusterRouteMonitor) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRouteMonitor.
+func (in *ClusterRouteMonitor) DeepCopy() *ClusterRouteMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRouteMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRouteMonitor) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRouteMonitorList) DeepCopyInto(out *ClusterRouteMonitorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRouteMonitor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRouteMonitorList.
+func (in *ClusterRouteMonitorList) DeepCopy() *ClusterRouteMonitorList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRouteMonitorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRouteMonitorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRouteMonitorSpec) DeepCopyInto(out *ClusterRouteMonitorSpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.RouteSelector.DeepCopyInto(&out.RouteSelector)
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(RouteMonitorHTTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRouteMonitorSpec.
+func (in *ClusterRouteMonitorSpec) DeepCopy() *ClusterRouteMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRouteMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRouteMonitorStatus) DeepCopyInto(out *ClusterRouteMonitorStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRouteMonitorStatus.
+func (in *ClusterRouteMonitorStatus) DeepCopy() *ClusterRouteMonitorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRouteMonitorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeRBACProxyConfig) DeepCopyInto(out *KubeRBACProxyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeRBACProxyConfig.
+func (in *KubeRBACProxyConfig) DeepCopy() *KubeRBACProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeRBACProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeDefaults) DeepCopyInto(out *ProbeDefaults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeDefaults.
+func (in *ProbeDefaults) DeepCopy() *ProbeDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteMonitor) DeepCopyInto(out *RouteMonitor) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitor.
@@ -51,6 +261,85 @@ func (in *RouteMonitor) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorAlerting) DeepCopyInto(out *RouteMonitorAlerting) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorAlerting.
+func (in *RouteMonitorAlerting) DeepCopy() *RouteMonitorAlerting {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorAlerting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorEffectiveProbe) DeepCopyInto(out *RouteMonitorEffectiveProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorEffectiveProbe.
+func (in *RouteMonitorEffectiveProbe) DeepCopy() *RouteMonitorEffectiveProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorEffectiveProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorHTTPSpec) DeepCopyInto(out *RouteMonitorHTTPSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ValidStatusCodes != nil {
+		in, out := &in.ValidStatusCodes, &out.ValidStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailIfBodyMatchesRegexp != nil {
+		in, out := &in.FailIfBodyMatchesRegexp, &out.FailIfBodyMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailIfBodyNotMatchesRegexp != nil {
+		in, out := &in.FailIfBodyNotMatchesRegexp, &out.FailIfBodyNotMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FollowRedirects != nil {
+		in, out := &in.FollowRedirects, &out.FollowRedirects
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorHTTPSpec.
+func (in *RouteMonitorHTTPSpec) DeepCopy() *RouteMonitorHTTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorHTTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteMonitorList) DeepCopyInto(out *RouteMonitorList) {
 	*out = *in
@@ -83,6 +372,82 @@ func (in *RouteMonitorList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorOperatorConfig) DeepCopyInto(out *RouteMonitorOperatorConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorOperatorConfig.
+func (in *RouteMonitorOperatorConfig) DeepCopy() *RouteMonitorOperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorOperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteMonitorOperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorOperatorConfigList) DeepCopyInto(out *RouteMonitorOperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RouteMonitorOperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorOperatorConfigList.
+func (in *RouteMonitorOperatorConfigList) DeepCopy() *RouteMonitorOperatorConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorOperatorConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteMonitorOperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorOperatorConfigSpec) DeepCopyInto(out *RouteMonitorOperatorConfigSpec) {
+	*out = *in
+	in.BlackBoxExporter.DeepCopyInto(&out.BlackBoxExporter)
+	out.ProbeDefaults = in.ProbeDefaults
+	in.ServiceMonitor.DeepCopyInto(&out.ServiceMonitor)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorOperatorConfigSpec.
+func (in *RouteMonitorOperatorConfigSpec) DeepCopy() *RouteMonitorOperatorConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorOperatorConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteMonitorRouteSpec) DeepCopyInto(out *RouteMonitorRouteSpec) {
 	*out = *in
@@ -102,6 +467,21 @@ func (in *RouteMonitorRouteSpec) DeepCopy() *RouteMonitorRouteSpec {
 func (in *RouteMonitorSpec) DeepCopyInto(out *RouteMonitorSpec) {
 	*out = *in
 	out.Route = in.Route
+	if in.RouteSelector != nil {
+		in, out := &in.RouteSelector, &out.RouteSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(RouteMonitorHTTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(RouteMonitorAlerting)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorSpec.
@@ -117,6 +497,24 @@ func (in *RouteMonitorSpec) DeepCopy() *RouteMonitorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteMonitorStatus) DeepCopyInto(out *RouteMonitorStatus) {
 	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RouteURLs != nil {
+		in, out := &in.RouteURLs, &out.RouteURLs
+		*out = make([]RouteMonitorURL, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.EffectiveProbe = in.EffectiveProbe
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorStatus.
@@ -128,3 +526,40 @@ func (in *RouteMonitorStatus) DeepCopy() *RouteMonitorStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorURL) DeepCopyInto(out *RouteMonitorURL) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorURL.
+func (in *RouteMonitorURL) DeepCopy() *RouteMonitorURL {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorURL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMonitorConfig) DeepCopyInto(out *ServiceMonitorConfig) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitorConfig.
+func (in *ServiceMonitorConfig) DeepCopy() *ServiceMonitorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMonitorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,154 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &RouteMonitor{}
+
+// ConvertTo converts the RouteMonitor to the v1alpha1 hub
+func (src *RouteMonitor) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.RouteMonitor)
+	dst.ObjectMeta = src.ObjectMeta
+	routeNamespace, hasRouteNamespace := src.Annotations[routemonitorconst.ConvertedRouteNamespaceAnnotation]
+	if hasRouteNamespace {
+		dst.Annotations = withoutAnnotation(src.Annotations, routemonitorconst.ConvertedRouteNamespaceAnnotation)
+	}
+
+	dst.Spec = v1alpha1.RouteMonitorSpec{
+		RouteSelector: src.Spec.Target.RouteSelector,
+		URL:           src.Spec.Target.URL,
+		Interval:      src.Spec.Probe.Interval,
+		ScrapeTimeout: src.Spec.Probe.ScrapeTimeout,
+		HealthPath:    src.Spec.Probe.HealthPath,
+		Module:        src.Spec.Probe.Module,
+		Backend:       src.Spec.Probe.Backend,
+	}
+	if src.Spec.Target.Route != nil {
+		// the Route of a v1beta1 RouteMonitor is always in its own namespace
+		dst.Spec.Route = v1alpha1.RouteMonitorRouteSpec{
+			Name:      src.Spec.Target.Route.Name,
+			Namespace: src.Namespace,
+		}
+	}
+	if hasRouteNamespace {
+		dst.Spec.Route.Namespace = routeNamespace
+	}
+	if src.Spec.Probe.HTTP != nil {
+		httpSpec := v1alpha1.RouteMonitorHTTPSpec(*src.Spec.Probe.HTTP)
+		dst.Spec.HTTP = &httpSpec
+	}
+	if src.Spec.Alerting != nil {
+		alerting := v1alpha1.RouteMonitorAlerting(*src.Spec.Alerting)
+		dst.Spec.Alerting = &alerting
+	}
+
+	dst.Status = v1alpha1.RouteMonitorStatus{
+		Routes:             src.Status.Routes,
+		ObservedGeneration: src.Status.ObservedGeneration,
+		EffectiveProbe:     v1alpha1.RouteMonitorEffectiveProbe(src.Status.EffectiveProbe),
+	}
+	for _, routeURL := range src.Status.URLs {
+		dst.Status.RouteURLs = append(dst.Status.RouteURLs, v1alpha1.RouteMonitorURL(routeURL))
+	}
+	for _, condition := range src.Status.Conditions {
+		dst.Status.Conditions = append(dst.Status.Conditions, v1alpha1.Condition(condition))
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 hub to this version of the RouteMonitor
+func (dst *RouteMonitor) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.RouteMonitor)
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec = RouteMonitorSpec{
+		Target: RouteMonitorTarget{
+			RouteSelector: src.Spec.RouteSelector,
+			URL:           src.Spec.URL,
+		},
+		Probe: RouteMonitorProbe{
+			Interval:      src.Spec.Interval,
+			ScrapeTimeout: src.Spec.ScrapeTimeout,
+			HealthPath:    src.Spec.HealthPath,
+			Module:        src.Spec.Module,
+			Backend:       src.Spec.Backend,
+		},
+	}
+	if src.Spec.Route.Name != "" {
+		dst.Spec.Target.Route = &RouteReference{Name: src.Spec.Route.Name}
+	}
+	// v1beta1 has no namespace for the Route, so one ConvertTo would not fill in is kept in an annotation
+	convertedRouteNamespace := ""
+	if dst.Spec.Target.Route != nil {
+		convertedRouteNamespace = src.Namespace
+	}
+	if src.Spec.Route.Namespace != convertedRouteNamespace {
+		dst.Annotations = withAnnotation(src.Annotations, routemonitorconst.ConvertedRouteNamespaceAnnotation, src.Spec.Route.Namespace)
+	}
+	if src.Spec.HTTP != nil {
+		httpSpec := RouteMonitorHTTPSpec(*src.Spec.HTTP)
+		dst.Spec.Probe.HTTP = &httpSpec
+	}
+	if src.Spec.Alerting != nil {
+		alerting := RouteMonitorAlerting(*src.Spec.Alerting)
+		dst.Spec.Alerting = &alerting
+	}
+
+	dst.Status = RouteMonitorStatus{
+		Routes:             src.Status.Routes,
+		ObservedGeneration: src.Status.ObservedGeneration,
+		EffectiveProbe:     RouteMonitorEffectiveProbe(src.Status.EffectiveProbe),
+	}
+	for _, routeURL := range src.Status.RouteURLs {
+		dst.Status.URLs = append(dst.Status.URLs, RouteMonitorURL(routeURL))
+	}
+	for _, condition := range src.Status.Conditions {
+		dst.Status.Conditions = append(dst.Status.Conditions, Condition(condition))
+	}
+	return nil
+}
+
+// withAnnotation returns a copy of the annotations with the annotation set, leaving the annotations of the source object untouched
+func withAnnotation(annotations map[string]string, key, value string) map[string]string {
+	res := map[string]string{key: value}
+	for k, v := range annotations {
+		if k != key {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// withoutAnnotation returns a copy of the annotations without the annotation, or nil if none remain
+func withoutAnnotation(annotations map[string]string, key string) map[string]string {
+	var res map[string]string
+	for k, v := range annotations {
+		if k == key {
+			continue
+		}
+		if res == nil {
+			res = map[string]string{}
+		}
+		res[k] = v
+	}
+	return res
+}
@@ -0,0 +1,234 @@
+package v1beta1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/api/v1beta1"
+	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RouteMonitor Conversion", func() {
+	var (
+		followRedirects bool
+		status          v1alpha1.RouteMonitorStatus
+	)
+	BeforeEach(func() {
+		followRedirects = false
+		status = v1alpha1.RouteMonitorStatus{
+			Routes: []string{"route"},
+			RouteURLs: []v1alpha1.RouteMonitorURL{{
+				RouteName:  "route",
+				RouterName: "default",
+				URL:        "https://route.apps.example.com/healthz",
+				Scheme:     "https",
+				Host:       "route.apps.example.com",
+				Path:       "/healthz",
+			}},
+			ObservedGeneration: 2,
+			EffectiveProbe: v1alpha1.RouteMonitorEffectiveProbe{
+				Interval:      "30s",
+				ScrapeTimeout: "15s",
+				Module:        "http_2xx",
+				Backend:       v1alpha1.BackendServiceMonitor,
+			},
+			Conditions: []v1alpha1.Condition{{
+				Type:               v1alpha1.ConditionReady,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: 2,
+				Reason:             v1alpha1.ReasonReady,
+			}},
+		}
+	})
+
+	Describe("ConvertFrom", func() {
+		When("a v1alpha1 RouteMonitor names a Route", func() {
+			It("should reference the Route in the target and move the settings to the probe", func() {
+				// Arrange
+				hub := v1alpha1.RouteMonitor{
+					ObjectMeta: metav1.ObjectMeta{Name: "routemonitor", Namespace: "namespace"},
+					Spec: v1alpha1.RouteMonitorSpec{
+						Route:      v1alpha1.RouteMonitorRouteSpec{Name: "route", Namespace: "namespace"},
+						Interval:   "1m",
+						HealthPath: "/healthz",
+						HTTP:       &v1alpha1.RouteMonitorHTTPSpec{Method: "HEAD", FollowRedirects: &followRedirects},
+					},
+					Status: status,
+				}
+				res := v1beta1.RouteMonitor{}
+				// Act
+				err := res.ConvertFrom(&hub)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.Name).To(Equal("routemonitor"))
+				Expect(res.Spec.Target.Route).To(Equal(&v1beta1.RouteReference{Name: "route"}))
+				Expect(res.Spec.Target.RouteSelector).To(BeNil())
+				Expect(res.Spec.Probe.Interval).To(Equal("1m"))
+				Expect(res.Spec.Probe.HealthPath).To(Equal("/healthz"))
+				Expect(res.Spec.Probe.HTTP.Method).To(Equal("HEAD"))
+				Expect(res.Status.URLs).To(HaveLen(1))
+				Expect(res.Status.URLs[0].URL).To(Equal("https://route.apps.example.com/healthz"))
+				Expect(res.Status.Conditions[0].Type).To(Equal(v1alpha1.ConditionReady))
+			})
+		})
+		When("a v1alpha1 RouteMonitor names a Route in another namespace", func() {
+			It("should keep the namespace of the Route in an annotation", func() {
+				// Arrange
+				hub := v1alpha1.RouteMonitor{
+					ObjectMeta: metav1.ObjectMeta{Name: "routemonitor", Namespace: "namespace", Annotations: map[string]string{"team": "platform"}},
+					Spec: v1alpha1.RouteMonitorSpec{
+						Route: v1alpha1.RouteMonitorRouteSpec{Name: "route", Namespace: "other"},
+					},
+				}
+				res := v1beta1.RouteMonitor{}
+				// Act
+				err := res.ConvertFrom(&hub)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.Spec.Target.Route).To(Equal(&v1beta1.RouteReference{Name: "route"}))
+				Expect(res.Annotations).To(Equal(map[string]string{
+					"team": "platform",
+					routemonitorconst.ConvertedRouteNamespaceAnnotation: "other",
+				}))
+				Expect(hub.Annotations).To(Equal(map[string]string{"team": "platform"}))
+			})
+		})
+		When("a v1alpha1 RouteMonitor selects Routes", func() {
+			It("should select the Routes in the target", func() {
+				// Arrange
+				hub := v1alpha1.RouteMonitor{
+					Spec: v1alpha1.RouteMonitorSpec{
+						RouteSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "public"}},
+					},
+				}
+				res := v1beta1.RouteMonitor{}
+				// Act
+				err := res.ConvertFrom(&hub)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.Spec.Target.Route).To(BeNil())
+				Expect(res.Spec.Target.RouteSelector).To(Equal(hub.Spec.RouteSelector))
+				Expect(res.Annotations).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ConvertTo", func() {
+		When("a v1beta1 RouteMonitor references a Route", func() {
+			It("should name the Route in the namespace of the RouteMonitor", func() {
+				// Arrange
+				routeMonitor := v1beta1.RouteMonitor{
+					ObjectMeta: metav1.ObjectMeta{Name: "routemonitor", Namespace: "namespace"},
+					Spec: v1beta1.RouteMonitorSpec{
+						Target: v1beta1.RouteMonitorTarget{Route: &v1beta1.RouteReference{Name: "route"}},
+						Probe:  v1beta1.RouteMonitorProbe{Module: "tcp_connect"},
+					},
+				}
+				res := v1alpha1.RouteMonitor{}
+				// Act
+				err := routeMonitor.ConvertTo(&res)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.Spec.Route).To(Equal(v1alpha1.RouteMonitorRouteSpec{Name: "route", Namespace: "namespace"}))
+				Expect(res.Spec.Module).To(Equal("tcp_connect"))
+			})
+		})
+		When("a v1beta1 RouteMonitor probes a url", func() {
+			It("should keep the url", func() {
+				// Arrange
+				routeMonitor := v1beta1.RouteMonitor{
+					Spec: v1beta1.RouteMonitorSpec{
+						Target: v1beta1.RouteMonitorTarget{URL: "https://example.com/status"},
+					},
+				}
+				res := v1alpha1.RouteMonitor{}
+				// Act
+				err := routeMonitor.ConvertTo(&res)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.Spec.URL).To(Equal("https://example.com/status"))
+				Expect(res.Spec.Route).To(Equal(v1alpha1.RouteMonitorRouteSpec{}))
+			})
+		})
+	})
+
+	When("a v1alpha1 RouteMonitor with a Route namespace v1beta1 has no field for is converted to v1beta1 and back", func() {
+		var (
+			hub v1alpha1.RouteMonitor
+			res v1alpha1.RouteMonitor
+		)
+		BeforeEach(func() {
+			hub = v1alpha1.RouteMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "routemonitor", Namespace: "namespace"},
+			}
+			res = v1alpha1.RouteMonitor{}
+		})
+		JustBeforeEach(func() {
+			spoke := v1beta1.RouteMonitor{}
+			Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+			Expect(spoke.ConvertTo(&res)).To(Succeed())
+		})
+		When("the Route is in another namespace", func() {
+			BeforeEach(func() {
+				hub.Spec.Route = v1alpha1.RouteMonitorRouteSpec{Name: "route", Namespace: "other"}
+			})
+			It("should be unchanged", func() {
+				Expect(res).To(Equal(hub))
+			})
+		})
+		When("the Route has no namespace", func() {
+			BeforeEach(func() {
+				hub.Spec.Route = v1alpha1.RouteMonitorRouteSpec{Name: "route"}
+			})
+			It("should be unchanged", func() {
+				Expect(res).To(Equal(hub))
+			})
+		})
+		When("the Route has only a namespace", func() {
+			BeforeEach(func() {
+				hub.Spec.Route = v1alpha1.RouteMonitorRouteSpec{Namespace: "namespace"}
+			})
+			It("should be unchanged", func() {
+				Expect(res).To(Equal(hub))
+			})
+		})
+	})
+
+	When("a v1alpha1 RouteMonitor is converted to v1beta1 and back", func() {
+		It("should be unchanged", func() {
+			// Arrange
+			hub := v1alpha1.RouteMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "routemonitor", Namespace: "namespace", Generation: 2},
+				Spec: v1alpha1.RouteMonitorSpec{
+					Route:         v1alpha1.RouteMonitorRouteSpec{Name: "route", Namespace: "namespace"},
+					Interval:      "1m",
+					ScrapeTimeout: "10s",
+					HealthPath:    "/healthz",
+					Module:        "http_2xx",
+					Backend:       v1alpha1.BackendProbe,
+					HTTP: &v1alpha1.RouteMonitorHTTPSpec{
+						Headers:          map[string]string{"Accept": "text/html"},
+						ValidStatusCodes: []int{200},
+						FollowRedirects:  &followRedirects,
+					},
+					Alerting: &v1alpha1.RouteMonitorAlerting{
+						For:      "10m",
+						Severity: "critical",
+						Labels:   map[string]string{"team": "platform"},
+					},
+				},
+				Status: status,
+			}
+			spoke := v1beta1.RouteMonitor{}
+			res := v1alpha1.RouteMonitor{}
+			// Act
+			Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+			err := spoke.ConvertTo(&res)
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(hub))
+		})
+	})
+})
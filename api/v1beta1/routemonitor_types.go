@@ -0,0 +1,234 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RouteMonitorSpec defines the desired state of RouteMonitor
+type RouteMonitorSpec struct {
+	// Target is what the RouteMonitor probes, exactly one of its fields has to be set
+	Target RouteMonitorTarget `json:"target"`
+
+	// Probe configures how the Target is probed
+	// +optional
+	Probe RouteMonitorProbe `json:"probe,omitempty"`
+
+	// Alerting creates a PrometheusRule next to the ServiceMonitor, alerting when the probes fail
+	// +optional
+	Alerting *RouteMonitorAlerting `json:"alerting,omitempty"`
+}
+
+// RouteMonitorTarget is a single Route, the Routes selected by their labels or a plain url
+type RouteMonitorTarget struct {
+	// Route references a Route in the namespace of the RouteMonitor
+	// +optional
+	Route *RouteReference `json:"route,omitempty"`
+
+	// RouteSelector selects every Route in the namespace of the RouteMonitor by its labels.
+	// Each selected Route gets its own targets
+	// +optional
+	RouteSelector *metav1.LabelSelector `json:"routeSelector,omitempty"`
+
+	// URL is probed as is, for endpoints that are not exposed by a Route
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// RouteReference references a Route in the namespace of the RouteMonitor
+type RouteReference struct {
+	// Name is the name of the Route
+	Name string `json:"name"`
+}
+
+// RouteMonitorProbe configures how the blackbox exporter probes the Target
+type RouteMonitorProbe struct {
+	// Interval is how often the Target is probed, defaults to 30s
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// ScrapeTimeout is how long a probe may take before it fails, defaults to 15s.
+	// It has to be smaller than the Interval
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+
+	// HealthPath is appended to the path of the Target when probing, e.g. /healthz
+	// +optional
+	HealthPath string `json:"healthPath,omitempty"`
+
+	// Module is the blackbox exporter module used to probe the Target, defaults to http_2xx
+	// +optional
+	Module string `json:"module,omitempty"`
+
+	// HTTP customizes how the Target is probed, the operator generates a dedicated module from it.
+	// It can only be combined with http modules
+	// +optional
+	HTTP *RouteMonitorHTTPSpec `json:"http,omitempty"`
+
+	// Backend is the Prometheus Operator resource the probes are scraped with, ServiceMonitor or Probe.
+	// Defaults to the backend of the probe defaults of the operator
+	// +kubebuilder:validation:Enum=ServiceMonitor;Probe
+	// +optional
+	Backend string `json:"backend,omitempty"`
+}
+
+// RouteMonitorAlerting configures the alert raised when the probes of the RouteMonitor fail
+type RouteMonitorAlerting struct {
+	// For is how long the probes have to fail before the alert fires, defaults to 5m
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	For string `json:"for,omitempty"`
+	// Severity is set as the severity label of the alert, defaults to warning
+	// +optional
+	Severity string `json:"severity,omitempty"`
+	// Labels are added to the alert, e.g. to route it to a team
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// RouteMonitorHTTPSpec customizes the requests of the http prober
+type RouteMonitorHTTPSpec struct {
+	// Method is the HTTP method of the probe request, defaults to the method of the module
+	// +kubebuilder:validation:Enum=GET;HEAD;POST;PUT;PATCH;DELETE;OPTIONS
+	// +optional
+	Method string `json:"method,omitempty"`
+	// Headers are sent with the probe request
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body is sent with the probe request
+	// +optional
+	Body string `json:"body,omitempty"`
+	// ValidStatusCodes are the status codes a successful probe can return, defaults to any 2xx
+	// +optional
+	ValidStatusCodes []int `json:"validStatusCodes,omitempty"`
+	// FailIfBodyMatchesRegexp fails the probe if the response body matches one of the expressions
+	// +optional
+	FailIfBodyMatchesRegexp []string `json:"failIfBodyMatchesRegexp,omitempty"`
+	// FailIfBodyNotMatchesRegexp fails the probe if the response body does not match one of the expressions
+	// +optional
+	FailIfBodyNotMatchesRegexp []string `json:"failIfBodyNotMatchesRegexp,omitempty"`
+	// FollowRedirects decides if the probe follows redirects, defaults to true
+	// +optional
+	FollowRedirects *bool `json:"followRedirects,omitempty"`
+}
+
+// RouteMonitorStatus defines the observed state of RouteMonitor
+type RouteMonitorStatus struct {
+	// Routes are the names of the Routes that are monitored, it is empty when the Target is a url
+	// +optional
+	Routes []string `json:"routes,omitempty"`
+
+	// URLs are the urls that are probed, extracted from the admitted ingresses of the monitored Routes or the url of the Target
+	// +optional
+	URLs []RouteMonitorURL `json:"urls,omitempty"`
+
+	// ObservedGeneration is the generation of the RouteMonitor that was last reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe whether the Target is probed, and why not if it isn't
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// EffectiveProbe shows the probe settings in use: the ones of the spec, and the defaults of the operator for those left empty
+	// +optional
+	EffectiveProbe RouteMonitorEffectiveProbe `json:"effectiveProbe,omitempty"`
+}
+
+// RouteMonitorURL is a single url that is probed
+type RouteMonitorURL struct {
+	// RouteName is the name of the Route the url belongs to
+	RouteName string `json:"routeName,omitempty"`
+	// RouterName is the name of the router that admitted the ingress of the Route
+	RouterName string `json:"routerName,omitempty"`
+	// URL is the full url that is probed
+	URL string `json:"url"`
+	// Scheme is the scheme of the URL
+	Scheme string `json:"scheme,omitempty"`
+	// Host is the host of the URL
+	Host string `json:"host,omitempty"`
+	// Path is the path of the URL, joined with the HealthPath
+	Path string `json:"path,omitempty"`
+}
+
+// RouteMonitorEffectiveProbe holds the probe settings the RouteMonitor is probed with
+type RouteMonitorEffectiveProbe struct {
+	// Interval is how often the target is probed
+	Interval string `json:"interval,omitempty"`
+	// ScrapeTimeout is how long a probe may take before it fails
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+	// Module is the blackbox exporter module the target is probed with
+	Module string `json:"module,omitempty"`
+	// Backend is the resource the probes are scraped with
+	Backend string `json:"backend,omitempty"`
+}
+
+// Condition describes one aspect of the state of a RouteMonitor, it mirrors the upstream metav1.Condition
+type Condition struct {
+	// Type of the condition, e.g. Ready
+	Type string `json:"type"`
+	// Status of the condition, one of True, False or Unknown
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status metav1.ConditionStatus `json:"status"`
+	// ObservedGeneration is the generation of the RouteMonitor the condition was set for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastTransitionTime is the last time the condition changed its status
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Reason is a CamelCase word explaining the status
+	Reason string `json:"reason"`
+	// Message is a human readable explanation of the status
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Route",type=string,JSONPath=`.spec.target.route.name`
+// +kubebuilder:printcolumn:name="URL",type=string,priority=1,JSONPath=`.spec.target.url`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Message",type=string,priority=1,JSONPath=`.status.conditions[?(@.type=="Ready")].message`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RouteMonitor is the Schema for the routemonitors API
+type RouteMonitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouteMonitorSpec   `json:"spec,omitempty"`
+	Status RouteMonitorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouteMonitorList contains a list of RouteMonitor
+type RouteMonitorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouteMonitor `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RouteMonitor{}, &RouteMonitorList{})
+}
@@ -0,0 +1,156 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var routemonitorlog = logf.Log.WithName("routemonitor-resource")
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks of v1beta1,
+// together with the conversion webhook of the RouteMonitor
+func (r *RouteMonitor) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-monitoring-openshift-io-v1beta1-routemonitor,mutating=true,failurePolicy=fail,groups=monitoring.openshift.io,resources=routemonitors,verbs=create;update,versions=v1beta1,name=mroutemonitor.v1beta1.kb.io
+
+var _ webhook.Defaulter = &RouteMonitor{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+// Like in v1alpha1 the probe settings are left empty, so they follow the defaults of the operator.
+// The Route of the Target is always in the namespace of the RouteMonitor, so there is nothing to fill in
+func (r *RouteMonitor) Default() {
+	routemonitorlog.V(1).Info("default", "namespace", r.Namespace, "name", r.Name)
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-monitoring-openshift-io-v1beta1-routemonitor,mutating=false,failurePolicy=fail,groups=monitoring.openshift.io,resources=routemonitors,versions=v1beta1,name=vroutemonitor.v1beta1.kb.io
+
+var _ webhook.Validator = &RouteMonitor{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *RouteMonitor) ValidateCreate() error {
+	routemonitorlog.V(1).Info("validate create", "namespace", r.Namespace, "name", r.Name)
+	return r.toInvalidError(r.validateRouteMonitor())
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *RouteMonitor) ValidateUpdate(old runtime.Object) error {
+	routemonitorlog.V(1).Info("validate update", "namespace", r.Namespace, "name", r.Name)
+
+	// a RouteMonitor that is deleted has to be able to drop its finalizer, whatever its spec is
+	if r.DeletionTimestamp != nil {
+		return nil
+	}
+	// an update that leaves the spec as is, e.g. of the finalizers or the storage migration, is not rejected
+	// for a spec that was stored before the webhook validated it
+	if oldRouteMonitor, ok := old.(*RouteMonitor); ok && reflect.DeepEqual(r.Spec, oldRouteMonitor.Spec) {
+		return nil
+	}
+
+	allErrs := r.validateRouteMonitor()
+	if oldRouteMonitor, ok := old.(*RouteMonitor); ok && !reflect.DeepEqual(r.Spec.Target.Route, oldRouteMonitor.Spec.Target.Route) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "target", "route"), "is immutable, create a new RouteMonitor to monitor another Route"))
+	}
+	return r.toInvalidError(allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *RouteMonitor) ValidateDelete() error {
+	return nil
+}
+
+// validateRouteMonitor rejects the specs the reconcile would fail on, with the paths of the v1beta1 fields
+func (r *RouteMonitor) validateRouteMonitor() field.ErrorList {
+	allErrs := field.ErrorList{}
+	targetPath := field.NewPath("spec", "target")
+	probePath := field.NewPath("spec", "probe")
+	hub := r.toHub()
+
+	target := r.Spec.Target
+	targets := 0
+	if target.Route != nil {
+		targets++
+		if target.Route.Name == "" {
+			allErrs = append(allErrs, field.Required(targetPath.Child("route", "name"), "the Route to monitor has to be named"))
+		}
+	}
+	if target.RouteSelector != nil {
+		targets++
+		if _, err := metav1.LabelSelectorAsSelector(target.RouteSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(targetPath.Child("routeSelector"), target.RouteSelector, err.Error()))
+		}
+	}
+	if target.URL != "" {
+		targets++
+		if err := hub.ValidateURL(); err != nil {
+			allErrs = append(allErrs, field.Invalid(targetPath.Child("url"), target.URL, withoutInvalidCRPrefix(err)))
+		}
+	}
+	if targets != 1 {
+		allErrs = append(allErrs, field.Invalid(targetPath, target, "exactly one of route, routeSelector and url has to be set"))
+	}
+
+	if err := hub.ValidateProbeTimings(); err != nil {
+		// An interval that doesn't fit the default scrapeTimeout is the one to change
+		if _, parseErr := time.ParseDuration(hub.GetInterval()); parseErr != nil || r.Spec.Probe.ScrapeTimeout == "" {
+			allErrs = append(allErrs, field.Invalid(probePath.Child("interval"), r.Spec.Probe.Interval, withoutInvalidCRPrefix(err)))
+		} else {
+			allErrs = append(allErrs, field.Invalid(probePath.Child("scrapeTimeout"), r.Spec.Probe.ScrapeTimeout, withoutInvalidCRPrefix(err)))
+		}
+	}
+	if err := hub.ValidateModule(); err != nil {
+		allErrs = append(allErrs, field.Invalid(probePath.Child("module"), r.Spec.Probe.Module, withoutInvalidCRPrefix(err)))
+	}
+	return allErrs
+}
+
+// toHub converts the RouteMonitor, so the defaults and checks of v1alpha1 can be reused
+func (r *RouteMonitor) toHub() *v1alpha1.RouteMonitor {
+	hub := &v1alpha1.RouteMonitor{}
+	// the conversion cannot fail
+	_ = r.DeepCopy().ConvertTo(hub)
+	return hub
+}
+
+// toInvalidError wraps the errors into the error the API server returns for invalid objects
+func (r *RouteMonitor) toInvalidError(allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("RouteMonitor").GroupKind(), r.Name, allErrs)
+}
+
+// withoutInvalidCRPrefix drops the prefix of the reconcile errors, as the API server already reports the object as invalid
+func withoutInvalidCRPrefix(err error) string {
+	return strings.TrimPrefix(err.Error(), "Invalid CR: ")
+}
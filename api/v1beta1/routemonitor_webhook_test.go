@@ -0,0 +1,191 @@
+package v1beta1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/route-monitor-operator/api/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RouteMonitor Webhook", func() {
+	var (
+		routeMonitor v1beta1.RouteMonitor
+		spec         v1beta1.RouteMonitorSpec
+	)
+	BeforeEach(func() {
+		spec = v1beta1.RouteMonitorSpec{
+			Target: v1beta1.RouteMonitorTarget{
+				Route: &v1beta1.RouteReference{Name: "route"},
+			},
+		}
+	})
+	JustBeforeEach(func() {
+		routeMonitor = v1beta1.RouteMonitor{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "routemonitor",
+				Namespace: "namespace",
+			},
+			Spec: spec,
+		}
+	})
+
+	Describe("Default", func() {
+		When("the probe settings are empty", func() {
+			It("should leave them to the defaults of the operator", func() {
+				// Act
+				routeMonitor.Default()
+				// Assert
+				Expect(routeMonitor.Spec.Probe).To(Equal(v1beta1.RouteMonitorProbe{}))
+				Expect(routeMonitor.Spec.Target.Route).To(Equal(&v1beta1.RouteReference{Name: "route"}))
+			})
+		})
+	})
+
+	Describe("ValidateCreate", func() {
+		When("the target references a Route", func() {
+			It("should admit the RouteMonitor", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the target is a url", func() {
+			BeforeEach(func() {
+				spec.Target = v1beta1.RouteMonitorTarget{URL: "https://example.com/status"}
+			})
+			It("should admit the RouteMonitor", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the url is not absolute", func() {
+			BeforeEach(func() {
+				spec.Target = v1beta1.RouteMonitorTarget{URL: "example.com/status"}
+			})
+			It("should reject the RouteMonitor", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.target.url"))
+			})
+		})
+		When("the target is empty", func() {
+			BeforeEach(func() {
+				spec.Target = v1beta1.RouteMonitorTarget{}
+			})
+			It("should reject the RouteMonitor", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("exactly one of route, routeSelector and url"))
+			})
+		})
+		When("the target has a Route and a url", func() {
+			BeforeEach(func() {
+				spec.Target.URL = "https://example.com/status"
+			})
+			It("should reject the RouteMonitor", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.target"))
+			})
+		})
+		When("the scrapeTimeout is not smaller than the interval", func() {
+			BeforeEach(func() {
+				spec.Probe = v1beta1.RouteMonitorProbe{Interval: "10s", ScrapeTimeout: "30s"}
+			})
+			It("should reject the RouteMonitor on the probe", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.probe.scrapeTimeout"))
+			})
+		})
+		When("only the interval is set and it is not bigger than the default scrapeTimeout", func() {
+			BeforeEach(func() {
+				spec.Probe = v1beta1.RouteMonitorProbe{Interval: "10s"}
+			})
+			It("should reject the RouteMonitor on the interval", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.probe.interval"))
+			})
+		})
+		When("the module is unknown", func() {
+			BeforeEach(func() {
+				spec.Probe = v1beta1.RouteMonitorProbe{Module: "unknown"}
+			})
+			It("should reject the RouteMonitor on the probe", func() {
+				// Act
+				err := routeMonitor.ValidateCreate()
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.probe.module"))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var oldRouteMonitor *v1beta1.RouteMonitor
+		JustBeforeEach(func() {
+			oldRouteMonitor = routeMonitor.DeepCopy()
+		})
+		When("the Route changes", func() {
+			It("should reject the RouteMonitor as the Route is immutable", func() {
+				// Arrange
+				routeMonitor.Spec.Target.Route = &v1beta1.RouteReference{Name: "other-route"}
+				// Act
+				err := routeMonitor.ValidateUpdate(oldRouteMonitor)
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("immutable"))
+			})
+		})
+		When("the probe changes", func() {
+			It("should admit the RouteMonitor", func() {
+				// Arrange
+				routeMonitor.Spec.Probe.Interval = "1m"
+				// Act
+				err := routeMonitor.ValidateUpdate(oldRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("a stored invalid RouteMonitor is written without changing its spec", func() {
+			It("should admit the update, so it can be migrated to the storage version", func() {
+				// Arrange
+				oldRouteMonitor.Spec.Probe.Module = "unknown"
+				routeMonitor.Spec.Probe.Module = "unknown"
+				// Act
+				err := routeMonitor.ValidateUpdate(oldRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the spec of a stored invalid RouteMonitor changes", func() {
+			It("should reject the RouteMonitor", func() {
+				// Arrange
+				oldRouteMonitor.Spec.Probe.Module = "unknown"
+				routeMonitor.Spec.Probe.Module = "unknown"
+				routeMonitor.Spec.Probe.Interval = "1m"
+				// Act
+				err := routeMonitor.ValidateUpdate(oldRouteMonitor)
+				// Assert
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.probe.module"))
+			})
+		})
+	})
+})
@@ -0,0 +1,310 @@
+// +build !ignore_autogenerated
+
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitor) DeepCopyInto(out *RouteMonitor) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitor.
+func (in *RouteMonitor) DeepCopy() *RouteMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteMonitor) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorAlerting) DeepCopyInto(out *RouteMonitorAlerting) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorAlerting.
+func (in *RouteMonitorAlerting) DeepCopy() *RouteMonitorAlerting {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorAlerting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorEffectiveProbe) DeepCopyInto(out *RouteMonitorEffectiveProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorEffectiveProbe.
+func (in *RouteMonitorEffectiveProbe) DeepCopy() *RouteMonitorEffectiveProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorEffectiveProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorHTTPSpec) DeepCopyInto(out *RouteMonitorHTTPSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ValidStatusCodes != nil {
+		in, out := &in.ValidStatusCodes, &out.ValidStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailIfBodyMatchesRegexp != nil {
+		in, out := &in.FailIfBodyMatchesRegexp, &out.FailIfBodyMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailIfBodyNotMatchesRegexp != nil {
+		in, out := &in.FailIfBodyNotMatchesRegexp, &out.FailIfBodyNotMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FollowRedirects != nil {
+		in, out := &in.FollowRedirects, &out.FollowRedirects
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorHTTPSpec.
+func (in *RouteMonitorHTTPSpec) DeepCopy() *RouteMonitorHTTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorHTTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorList) DeepCopyInto(out *RouteMonitorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RouteMonitor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorList.
+func (in *RouteMonitorList) DeepCopy() *RouteMonitorList {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteMonitorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorProbe) DeepCopyInto(out *RouteMonitorProbe) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(RouteMonitorHTTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorProbe.
+func (in *RouteMonitorProbe) DeepCopy() *RouteMonitorProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorSpec) DeepCopyInto(out *RouteMonitorSpec) {
+	*out = *in
+	in.Target.DeepCopyInto(&out.Target)
+	in.Probe.DeepCopyInto(&out.Probe)
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(RouteMonitorAlerting)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorSpec.
+func (in *RouteMonitorSpec) DeepCopy() *RouteMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorStatus) DeepCopyInto(out *RouteMonitorStatus) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]RouteMonitorURL, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.EffectiveProbe = in.EffectiveProbe
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorStatus.
+func (in *RouteMonitorStatus) DeepCopy() *RouteMonitorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorTarget) DeepCopyInto(out *RouteMonitorTarget) {
+	*out = *in
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(RouteReference)
+		**out = **in
+	}
+	if in.RouteSelector != nil {
+		in, out := &in.RouteSelector, &out.RouteSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorTarget.
+func (in *RouteMonitorTarget) DeepCopy() *RouteMonitorTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorURL) DeepCopyInto(out *RouteMonitorURL) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorURL.
+func (in *RouteMonitorURL) DeepCopy() *RouteMonitorURL {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorURL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteReference) DeepCopyInto(out *RouteReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteReference.
+func (in *RouteReference) DeepCopy() *RouteReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteReference)
+	in.DeepCopyInto(out)
+	return out
+}
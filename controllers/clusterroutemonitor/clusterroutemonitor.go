@@ -0,0 +1,282 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterroutemonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
+	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
+)
+
+// ClusterRouteMonitorReconciler reconciles a ClusterRouteMonitor object.
+// It creates a RouteMonitor in every namespace holding selected Routes, those RouteMonitors are then probed like any other
+type ClusterRouteMonitorReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=clusterroutemonitors,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=clusterroutemonitors/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=clusterroutemonitors/finalizers,verbs=update
+// +kubebuilder:rbac:groups=*,resources=namespaces,verbs=get;list;watch
+
+func (r *ClusterRouteMonitorReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithName("Reconcile")
+
+	log.V(2).Info("Entering GetClusterRouteMonitor")
+	clusterRouteMonitor, res, err := r.GetClusterRouteMonitor(ctx, req)
+	if err != nil {
+		return utilreconcile.RequeueWith(err)
+	}
+	if res.ShouldStop() {
+		return utilreconcile.Stop()
+	}
+
+	// The RouteMonitors are owned by the ClusterRouteMonitor, the garbage collector removes them
+	if clusterRouteMonitor.DeletionTimestamp != nil {
+		return utilreconcile.Stop()
+	}
+
+	fetchedStatus := *clusterRouteMonitor.Status.DeepCopy()
+
+	log.V(2).Info("Entering GetSelectedNamespaces")
+	namespaces, err := r.GetSelectedNamespaces(ctx, clusterRouteMonitor)
+	if err != nil {
+		return r.failReconcile(ctx, clusterRouteMonitor, fetchedStatus, err)
+	}
+
+	log.V(2).Info("Entering EnsureRouteMonitorsExist")
+	if err := r.EnsureRouteMonitorsExist(ctx, clusterRouteMonitor, namespaces); err != nil {
+		return r.failReconcile(ctx, clusterRouteMonitor, fetchedStatus, err)
+	}
+
+	log.V(2).Info("Entering EnsureStaleRouteMonitorsAbsent")
+	if err := r.EnsureStaleRouteMonitorsAbsent(ctx, clusterRouteMonitor, namespaces); err != nil {
+		return r.failReconcile(ctx, clusterRouteMonitor, fetchedStatus, err)
+	}
+
+	clusterRouteMonitor.Status.Namespaces = namespaces
+	if len(namespaces) == 0 {
+		clusterRouteMonitor.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonRouteNotFound, "No Route matches the selectors")
+	} else {
+		clusterRouteMonitor.SetCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonResourcesExist, fmt.Sprintf("RouteMonitors exist in %d namespace(s)", len(namespaces)))
+	}
+
+	log.V(2).Info("Entering EnsureStatusUpdated")
+	if err := r.EnsureStatusUpdated(ctx, clusterRouteMonitor, fetchedStatus); err != nil {
+		return utilreconcile.RequeueWith(err)
+	}
+	return utilreconcile.Stop()
+}
+
+// failReconcile records the error on the Ready condition before bubbling it up
+func (r *ClusterRouteMonitorReconciler) failReconcile(ctx context.Context, clusterRouteMonitor v1alpha1.ClusterRouteMonitor, fetchedStatus v1alpha1.ClusterRouteMonitorStatus, err error) (ctrl.Result, error) {
+	reason := v1alpha1.ReasonReconcileFailed
+	if errors.Is(err, customerrors.InvalidCR) {
+		reason = v1alpha1.ReasonInvalidSpec
+	}
+	clusterRouteMonitor.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, reason, err.Error())
+	if statusErr := r.EnsureStatusUpdated(ctx, clusterRouteMonitor, fetchedStatus); statusErr != nil {
+		r.Log.Error(statusErr, "Failed to update the conditions")
+	}
+	return utilreconcile.RequeueWith(err)
+}
+
+// GetClusterRouteMonitor return the ClusterRouteMonitor that is tested
+func (r *ClusterRouteMonitorReconciler) GetClusterRouteMonitor(ctx context.Context, req ctrl.Request) (v1alpha1.ClusterRouteMonitor, utilreconcile.Result, error) {
+	clusterRouteMonitor := v1alpha1.ClusterRouteMonitor{}
+	if err := r.Get(ctx, req.NamespacedName, &clusterRouteMonitor); err != nil {
+		if k8serrors.IsNotFound(err) {
+			r.Log.V(2).Info("StopRequeue", "As ClusterRouteMonitor is 'NotFound', stopping requeue", nil)
+			return v1alpha1.ClusterRouteMonitor{}, utilreconcile.StopOperation(), nil
+		}
+		return v1alpha1.ClusterRouteMonitor{}, utilreconcile.RequeueOperation(), err
+	}
+	return clusterRouteMonitor, utilreconcile.ContinueOperation(), nil
+}
+
+// GetSelectedNamespaces returns the sorted namespaces that are selected and hold at least one selected Route
+func (r *ClusterRouteMonitorReconciler) GetSelectedNamespaces(ctx context.Context, clusterRouteMonitor v1alpha1.ClusterRouteMonitor) ([]string, error) {
+	namespaceSelector, err := clusterRouteMonitor.GetNamespaceSelector()
+	if err != nil {
+		return nil, err
+	}
+	routeSelector, err := clusterRouteMonitor.GetRouteSelector()
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceList := corev1.NamespaceList{}
+	if err := r.List(ctx, &namespaceList, client.MatchingLabelsSelector{Selector: namespaceSelector}); err != nil {
+		return nil, err
+	}
+	selectedNamespaces := map[string]bool{}
+	for _, namespace := range namespaceList.Items {
+		selectedNamespaces[namespace.Name] = true
+	}
+
+	routeList := routev1.RouteList{}
+	if err := r.List(ctx, &routeList, client.MatchingLabelsSelector{Selector: routeSelector}); err != nil {
+		return nil, err
+	}
+	namespacesWithRoutes := map[string]bool{}
+	for _, route := range routeList.Items {
+		if selectedNamespaces[route.Namespace] {
+			namespacesWithRoutes[route.Namespace] = true
+		}
+	}
+
+	res := []string{}
+	for namespace := range namespacesWithRoutes {
+		res = append(res, namespace)
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+// EnsureRouteMonitorsExist creates or updates the RouteMonitor of every namespace,
+// RouteMonitors with the same name that are not owned by the ClusterRouteMonitor are left alone
+func (r *ClusterRouteMonitorReconciler) EnsureRouteMonitorsExist(ctx context.Context, clusterRouteMonitor v1alpha1.ClusterRouteMonitor, namespaces []string) error {
+	conflicts := []string{}
+	for _, namespace := range namespaces {
+		desired := clusterRouteMonitor.TemplateForRouteMonitor(namespace)
+		if err := desired.ValidateProbeTimings(); err != nil {
+			return err
+		}
+		if err := desired.ValidateModule(); err != nil {
+			return err
+		}
+
+		current := v1alpha1.RouteMonitor{}
+		err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &current)
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return err
+			}
+			if err := controllerutil.SetControllerReference(&clusterRouteMonitor, &desired, r.Scheme); err != nil {
+				return err
+			}
+			if err := r.Create(ctx, &desired); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !metav1.IsControlledBy(&current, &clusterRouteMonitor) {
+			conflicts = append(conflicts, fmt.Sprintf("%s/%s", current.Namespace, current.Name))
+			continue
+		}
+		if reflect.DeepEqual(current.Spec, desired.Spec) && reflect.DeepEqual(current.Labels, desired.Labels) {
+			continue
+		}
+		current.Spec = desired.Spec
+		current.Labels = desired.Labels
+		if err := r.Update(ctx, &current); err != nil {
+			return err
+		}
+	}
+
+	if len(conflicts) != 0 {
+		return fmt.Errorf("Conflict: RouteMonitors %s are not owned by the ClusterRouteMonitor", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// EnsureStaleRouteMonitorsAbsent deletes the owned RouteMonitors of namespaces that are no longer selected
+func (r *ClusterRouteMonitorReconciler) EnsureStaleRouteMonitorsAbsent(ctx context.Context, clusterRouteMonitor v1alpha1.ClusterRouteMonitor, namespaces []string) error {
+	routeMonitors := v1alpha1.RouteMonitorList{}
+	err := r.List(ctx, &routeMonitors, client.MatchingLabels{routemonitorconst.ClusterRouteMonitorLabel: clusterRouteMonitor.Name})
+	if err != nil {
+		return err
+	}
+
+	selectedNamespaces := map[string]bool{}
+	for _, namespace := range namespaces {
+		selectedNamespaces[namespace] = true
+	}
+	for i := range routeMonitors.Items {
+		routeMonitor := &routeMonitors.Items[i]
+		if selectedNamespaces[routeMonitor.Namespace] || !metav1.IsControlledBy(routeMonitor, &clusterRouteMonitor) {
+			continue
+		}
+		if err := r.Delete(ctx, routeMonitor); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnsureStatusUpdated writes the status set during the reconcile, skipping the write when nothing changed since fetchedStatus
+func (r *ClusterRouteMonitorReconciler) EnsureStatusUpdated(ctx context.Context, clusterRouteMonitor v1alpha1.ClusterRouteMonitor, fetchedStatus v1alpha1.ClusterRouteMonitorStatus) error {
+	clusterRouteMonitor.Status.ObservedGeneration = clusterRouteMonitor.Generation
+	if reflect.DeepEqual(clusterRouteMonitor.Status, fetchedStatus) {
+		r.Log.V(3).Info("Same Status: the status did not change, update not required")
+		return nil
+	}
+	return r.Status().Update(ctx, &clusterRouteMonitor)
+}
+
+func (r *ClusterRouteMonitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Routes and namespaces can start or stop matching any ClusterRouteMonitor
+	toAllClusterRouteMonitors := &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(r.requestsForAllClusterRouteMonitors),
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ClusterRouteMonitor{}).
+		Owns(&v1alpha1.RouteMonitor{}).
+		Watches(&source.Kind{Type: &routev1.Route{}}, toAllClusterRouteMonitors).
+		Watches(&source.Kind{Type: &corev1.Namespace{}}, toAllClusterRouteMonitors).
+		Complete(r)
+}
+
+// requestsForAllClusterRouteMonitors enqueues every ClusterRouteMonitor
+func (r *ClusterRouteMonitorReconciler) requestsForAllClusterRouteMonitors(_ handler.MapObject) []reconcile.Request {
+	clusterRouteMonitors := v1alpha1.ClusterRouteMonitorList{}
+	if err := r.List(context.Background(), &clusterRouteMonitors); err != nil {
+		r.Log.Error(err, "Failed to list the ClusterRouteMonitors")
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, clusterRouteMonitor := range clusterRouteMonitors.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: clusterRouteMonitor.Name}})
+	}
+	return requests
+}
@@ -0,0 +1,13 @@
+package clusterroutemonitor_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestClusterroutemonitor(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Clusterroutemonitor Suite")
+}
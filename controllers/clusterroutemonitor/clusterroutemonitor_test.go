@@ -0,0 +1,306 @@
+package clusterroutemonitor_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	//tested package
+	"github.com/openshift/route-monitor-operator/controllers/clusterroutemonitor"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
+	constinit "github.com/openshift/route-monitor-operator/pkg/const/test/init"
+	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
+)
+
+var _ = Describe("Clusterroutemonitor", func() {
+	var (
+		ctx                           context.Context
+		clusterRouteMonitorReconciler clusterroutemonitor.ClusterRouteMonitorReconciler
+		clusterRouteMonitorClient     client.Client
+		clusterRouteMonitor           v1alpha1.ClusterRouteMonitor
+		clusterRouteMonitorSpec       v1alpha1.ClusterRouteMonitorSpec
+		objects                       []runtime.Object
+		publicLabels, tenantLabels    map[string]string
+		namespaceObject, routeObject  func(name string, labels map[string]string) runtime.Object
+		ownedRouteMonitorInNamespace  func(namespace string) *v1alpha1.RouteMonitor
+		routeObjectInNamespace        func(namespace, name string, labels map[string]string) runtime.Object
+		getRouteMonitor               func(namespace string) (v1alpha1.RouteMonitor, error)
+		addObjects                    func()
+	)
+	BeforeEach(func() {
+		ctx = constinit.Context
+		objects = []runtime.Object{}
+		publicLabels = map[string]string{"tier": "public"}
+		tenantLabels = map[string]string{"tenant": "true"}
+		clusterRouteMonitorSpec = v1alpha1.ClusterRouteMonitorSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: tenantLabels},
+			RouteSelector:     metav1.LabelSelector{MatchLabels: publicLabels},
+		}
+		addObjects = func() {}
+
+		namespaceObject = func(name string, labels map[string]string) runtime.Object {
+			return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+		}
+		routeObjectInNamespace = func(namespace, name string, labels map[string]string) runtime.Object {
+			return &routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}}
+		}
+		routeObject = func(namespace string, labels map[string]string) runtime.Object {
+			return routeObjectInNamespace(namespace, "route", labels)
+		}
+		ownedRouteMonitorInNamespace = func(namespace string) *v1alpha1.RouteMonitor {
+			routeMonitor := clusterRouteMonitor.TemplateForRouteMonitor(namespace)
+			Expect(controllerutil.SetControllerReference(&clusterRouteMonitor, &routeMonitor, constinit.Scheme)).To(Succeed())
+			return &routeMonitor
+		}
+		getRouteMonitor = func(namespace string) (v1alpha1.RouteMonitor, error) {
+			routeMonitor := v1alpha1.RouteMonitor{}
+			err := clusterRouteMonitorClient.Get(ctx, types.NamespacedName{Name: clusterRouteMonitor.TemplateForRouteMonitorName(), Namespace: namespace}, &routeMonitor)
+			return routeMonitor, err
+		}
+	})
+	JustBeforeEach(func() {
+		clusterRouteMonitor = v1alpha1.ClusterRouteMonitor{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "ClusterRouteMonitor",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "public",
+				UID:  "fake-uid",
+			},
+			Spec: clusterRouteMonitorSpec,
+		}
+		addObjects()
+		clusterRouteMonitorClient = fake.NewFakeClientWithScheme(constinit.Scheme, append(objects, clusterRouteMonitor.DeepCopy())...)
+		clusterRouteMonitorReconciler = clusterroutemonitor.ClusterRouteMonitorReconciler{
+			Client: clusterRouteMonitorClient,
+			Log:    constinit.Logger,
+			Scheme: constinit.Scheme,
+		}
+	})
+
+	Describe("GetClusterRouteMonitor", func() {
+		When("the ClusterRouteMonitor is not found", func() {
+			It("should stop processing", func() {
+				// Act
+				_, res, err := clusterRouteMonitorReconciler.GetClusterRouteMonitor(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "missing"}})
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.StopOperation()))
+			})
+		})
+		When("the ClusterRouteMonitor exists", func() {
+			It("should return it", func() {
+				// Act
+				resClusterRouteMonitor, res, err := clusterRouteMonitorReconciler.GetClusterRouteMonitor(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "public"}})
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.ContinueOperation()))
+				Expect(resClusterRouteMonitor.Name).To(Equal("public"))
+			})
+		})
+	})
+
+	Describe("GetSelectedNamespaces", func() {
+		When("Routes exist in selected and unselected namespaces", func() {
+			// Arrange
+			BeforeEach(func() {
+				objects = append(objects,
+					namespaceObject("tenant-b", tenantLabels),
+					namespaceObject("tenant-a", tenantLabels),
+					namespaceObject("tenant-without-routes", tenantLabels),
+					namespaceObject("kube-system", nil),
+					routeObject("tenant-b", publicLabels),
+					routeObject("tenant-a", publicLabels),
+					routeObjectInNamespace("tenant-a", "second-route", publicLabels),
+					routeObject("tenant-without-routes", nil),
+					routeObject("kube-system", publicLabels),
+				)
+			})
+			It("should return the sorted selected namespaces holding selected Routes", func() {
+				// Act
+				namespaces, err := clusterRouteMonitorReconciler.GetSelectedNamespaces(ctx, clusterRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(namespaces).To(Equal([]string{"tenant-a", "tenant-b"}))
+			})
+		})
+		When("the NamespaceSelector is not set", func() {
+			// Arrange
+			BeforeEach(func() {
+				clusterRouteMonitorSpec.NamespaceSelector = nil
+				objects = append(objects,
+					namespaceObject("tenant-a", tenantLabels),
+					namespaceObject("kube-system", nil),
+					routeObject("tenant-a", publicLabels),
+					routeObject("kube-system", publicLabels),
+				)
+			})
+			It("should select every namespace", func() {
+				// Act
+				namespaces, err := clusterRouteMonitorReconciler.GetSelectedNamespaces(ctx, clusterRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(namespaces).To(Equal([]string{"kube-system", "tenant-a"}))
+			})
+		})
+		When("the RouteSelector cannot be parsed", func() {
+			// Arrange
+			BeforeEach(func() {
+				clusterRouteMonitorSpec.RouteSelector = metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Near"}},
+				}
+			})
+			It("should return an Invalid CR error", func() {
+				// Act
+				_, err := clusterRouteMonitorReconciler.GetSelectedNamespaces(ctx, clusterRouteMonitor)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+	})
+
+	Describe("EnsureRouteMonitorsExist", func() {
+		When("the RouteMonitor does not exist", func() {
+			It("should create it owned by the ClusterRouteMonitor", func() {
+				// Act
+				err := clusterRouteMonitorReconciler.EnsureRouteMonitorsExist(ctx, clusterRouteMonitor, []string{"tenant-a"})
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				routeMonitor, err := getRouteMonitor("tenant-a")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metav1.IsControlledBy(&routeMonitor, &clusterRouteMonitor)).To(BeTrue())
+				Expect(routeMonitor.Labels).To(HaveKeyWithValue(routemonitorconst.ClusterRouteMonitorLabel, "public"))
+				Expect(routeMonitor.Spec.RouteSelector).To(Equal(&clusterRouteMonitorSpec.RouteSelector))
+			})
+		})
+		When("the RouteMonitor differs from the ClusterRouteMonitor", func() {
+			// Arrange
+			BeforeEach(func() {
+				clusterRouteMonitorSpec.Interval = "1m"
+				addObjects = func() {
+					routeMonitor := ownedRouteMonitorInNamespace("tenant-a")
+					routeMonitor.Spec.Interval = "5m"
+					objects = append(objects, routeMonitor)
+				}
+			})
+			It("should update it", func() {
+				// Act
+				err := clusterRouteMonitorReconciler.EnsureRouteMonitorsExist(ctx, clusterRouteMonitor, []string{"tenant-a"})
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				routeMonitor, err := getRouteMonitor("tenant-a")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitor.Spec.Interval).To(Equal("1m"))
+			})
+		})
+		When("a RouteMonitor with the same name is not owned by the ClusterRouteMonitor", func() {
+			// Arrange
+			BeforeEach(func() {
+				addObjects = func() {
+					routeMonitor := clusterRouteMonitor.TemplateForRouteMonitor("tenant-a")
+					routeMonitor.Labels = nil
+					routeMonitor.Spec.Interval = "5m"
+					objects = append(objects, &routeMonitor)
+				}
+			})
+			It("should leave it alone and return a Conflict error", func() {
+				// Act
+				err := clusterRouteMonitorReconciler.EnsureRouteMonitorsExist(ctx, clusterRouteMonitor, []string{"tenant-a", "tenant-b"})
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Conflict:"))
+				routeMonitor, err := getRouteMonitor("tenant-a")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitor.Spec.Interval).To(Equal("5m"))
+				_, err = getRouteMonitor("tenant-b")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the ClusterRouteMonitor has an invalid module", func() {
+			// Arrange
+			BeforeEach(func() {
+				clusterRouteMonitorSpec.Module = "not_a_module"
+			})
+			It("should return an Invalid CR error", func() {
+				// Act
+				err := clusterRouteMonitorReconciler.EnsureRouteMonitorsExist(ctx, clusterRouteMonitor, []string{"tenant-a"})
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+	})
+
+	Describe("EnsureStaleRouteMonitorsAbsent", func() {
+		When("a namespace is no longer selected", func() {
+			// Arrange
+			BeforeEach(func() {
+				addObjects = func() {
+					objects = append(objects, ownedRouteMonitorInNamespace("tenant-a"), ownedRouteMonitorInNamespace("tenant-b"))
+				}
+			})
+			It("should delete its RouteMonitor only", func() {
+				// Act
+				err := clusterRouteMonitorReconciler.EnsureStaleRouteMonitorsAbsent(ctx, clusterRouteMonitor, []string{"tenant-a"})
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				_, err = getRouteMonitor("tenant-a")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = getRouteMonitor("tenant-b")
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+		When("a labelled RouteMonitor is not owned by the ClusterRouteMonitor", func() {
+			// Arrange
+			BeforeEach(func() {
+				addObjects = func() {
+					routeMonitor := ownedRouteMonitorInNamespace("tenant-b")
+					routeMonitor.OwnerReferences = nil
+					objects = append(objects, routeMonitor)
+				}
+			})
+			It("should leave it alone", func() {
+				// Act
+				err := clusterRouteMonitorReconciler.EnsureStaleRouteMonitorsAbsent(ctx, clusterRouteMonitor, []string{})
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				_, err = getRouteMonitor("tenant-b")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("EnsureStatusUpdated", func() {
+		When("the status changed", func() {
+			It("should write it with the observed generation", func() {
+				// Arrange
+				clusterRouteMonitor.Generation = 2
+				clusterRouteMonitor.Status.Namespaces = []string{"tenant-a"}
+				// Act
+				err := clusterRouteMonitorReconciler.EnsureStatusUpdated(ctx, clusterRouteMonitor, v1alpha1.ClusterRouteMonitorStatus{})
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				resClusterRouteMonitor := v1alpha1.ClusterRouteMonitor{}
+				Expect(clusterRouteMonitorClient.Get(ctx, types.NamespacedName{Name: "public"}, &resClusterRouteMonitor)).To(Succeed())
+				Expect(resClusterRouteMonitor.Status.Namespaces).To(Equal([]string{"tenant-a"}))
+				Expect(resClusterRouteMonitor.Status.ObservedGeneration).To(Equal(int64(2)))
+			})
+		})
+	})
+})
@@ -0,0 +1,195 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
+	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
+)
+
+// RouteReconciler creates a RouteMonitor for every Route that opts in to monitoring through its annotations.
+// The RouteMonitor is owned by the Route, so it is garbage collected with it
+type RouteReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=*,resources=events,verbs=create;patch
+
+func (r *RouteReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithName("Reconcile")
+
+	log.V(2).Info("Entering GetRoute")
+	route, res, err := r.GetRoute(ctx, req)
+	if err != nil {
+		return utilreconcile.RequeueWith(err)
+	}
+	if res.ShouldStop() {
+		return utilreconcile.Stop()
+	}
+
+	if !IsMonitoringEnabled(route) {
+		log.V(2).Info("Entering EnsureRouteMonitorAbsent")
+		if err := r.EnsureRouteMonitorAbsent(ctx, route); err != nil {
+			return utilreconcile.RequeueWith(err)
+		}
+		return utilreconcile.Stop()
+	}
+
+	log.V(2).Info("Entering EnsureRouteMonitorExists")
+	if err := r.EnsureRouteMonitorExists(ctx, route); err != nil {
+		// A retry cannot fix the annotations, the Route is skipped until they are edited
+		if errors.Is(err, customerrors.InvalidCR) {
+			log.Info("Invalid annotations: skipping the Route", "route", req.NamespacedName.String(), "error", err.Error())
+			r.Recorder.Event(&route, corev1.EventTypeWarning, "InvalidAnnotations", err.Error())
+			return utilreconcile.Stop()
+		}
+		return utilreconcile.RequeueWith(err)
+	}
+	return utilreconcile.Stop()
+}
+
+// GetRoute returns the Route of the request, stopping when it is gone or being deleted as the garbage collector removes its RouteMonitor
+func (r *RouteReconciler) GetRoute(ctx context.Context, req ctrl.Request) (routev1.Route, utilreconcile.Result, error) {
+	route := routev1.Route{}
+	if err := r.Get(ctx, req.NamespacedName, &route); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return routev1.Route{}, utilreconcile.StopOperation(), nil
+		}
+		return routev1.Route{}, utilreconcile.RequeueOperation(), err
+	}
+	if route.DeletionTimestamp != nil {
+		return routev1.Route{}, utilreconcile.StopOperation(), nil
+	}
+	return route, utilreconcile.ContinueOperation(), nil
+}
+
+// IsMonitoringEnabled verifies that the Route opted in to monitoring
+func IsMonitoringEnabled(route routev1.Route) bool {
+	return route.Annotations[routemonitorconst.RouteMonitoringEnabledAnnotation] == "true"
+}
+
+// EnsureRouteMonitorExists creates or updates the RouteMonitor of the Route,
+// a RouteMonitor with the same name that is not owned by the Route is left alone.
+// Annotations the RouteMonitor would be rejected for are returned as an Invalid CR error, without touching the RouteMonitor
+func (r *RouteReconciler) EnsureRouteMonitorExists(ctx context.Context, route routev1.Route) error {
+	desired := templateForRouteMonitor(route)
+	if err := desired.ValidateProbeTimings(); err != nil {
+		return err
+	}
+	if err := desired.ValidateModule(); err != nil {
+		return err
+	}
+
+	current := v1alpha1.RouteMonitor{}
+	err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &current)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if err := controllerutil.SetControllerReference(&route, &desired, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(ctx, &desired)
+	}
+
+	if !metav1.IsControlledBy(&current, &route) {
+		return fmt.Errorf("Conflict: RouteMonitor %s/%s is not owned by the Route", current.Namespace, current.Name)
+	}
+	if reflect.DeepEqual(current.Spec, desired.Spec) {
+		return nil
+	}
+	current.Spec = desired.Spec
+	return r.Update(ctx, &current)
+}
+
+// EnsureRouteMonitorAbsent deletes the RouteMonitor of a Route that no longer opts in to monitoring
+func (r *RouteReconciler) EnsureRouteMonitorAbsent(ctx context.Context, route routev1.Route) error {
+	routeMonitor := v1alpha1.RouteMonitor{}
+	err := r.Get(ctx, types.NamespacedName{Name: TemplateForRouteMonitorName(route), Namespace: route.Namespace}, &routeMonitor)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(&routeMonitor, &route) {
+		return nil
+	}
+	if err := r.Delete(ctx, &routeMonitor); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// TemplateForRouteMonitorName returns the name of the RouteMonitor created for a Route
+func TemplateForRouteMonitorName(route routev1.Route) string {
+	return fmt.Sprintf("route-%s", route.Name)
+}
+
+// templateForRouteMonitor returns the RouteMonitor of a Route, configured by the annotations of the Route
+func templateForRouteMonitor(route routev1.Route) v1alpha1.RouteMonitor {
+	routeMonitor := v1alpha1.RouteMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TemplateForRouteMonitorName(route),
+			Namespace: route.Namespace,
+		},
+		Spec: v1alpha1.RouteMonitorSpec{
+			Route: v1alpha1.RouteMonitorRouteSpec{
+				Name:      route.Name,
+				Namespace: route.Namespace,
+			},
+			Interval:      route.Annotations[routemonitorconst.RouteIntervalAnnotation],
+			ScrapeTimeout: route.Annotations[routemonitorconst.RouteScrapeTimeoutAnnotation],
+			Module:        route.Annotations[routemonitorconst.RouteModuleAnnotation],
+			HealthPath:    route.Annotations[routemonitorconst.RouteHealthPathAnnotation],
+		},
+	}
+	// match what the defaulting webhook stores, otherwise the RouteMonitor is updated on every reconcile
+	routeMonitor.Default()
+	return routeMonitor
+}
+
+func (r *RouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&routev1.Route{}).
+		Owns(&v1alpha1.RouteMonitor{}).
+		Complete(r)
+}
@@ -0,0 +1,258 @@
+package route_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	//tested package
+	"github.com/openshift/route-monitor-operator/controllers/route"
+
+	routev1 "github.com/openshift/api/route/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
+	constinit "github.com/openshift/route-monitor-operator/pkg/const/test/init"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
+	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
+)
+
+var _ = Describe("Route", func() {
+	var (
+		ctx              context.Context
+		routeReconciler  route.RouteReconciler
+		routeClient      client.Client
+		routeObject      routev1.Route
+		routeAnnotations map[string]string
+		objects          []runtime.Object
+		addObjects       func()
+		getRouteMonitor  func() (v1alpha1.RouteMonitor, error)
+		recorder         *record.FakeRecorder
+	)
+	BeforeEach(func() {
+		ctx = constinit.Context
+		objects = []runtime.Object{}
+		addObjects = func() {}
+		routeAnnotations = map[string]string{
+			routemonitorconst.RouteMonitoringEnabledAnnotation: "true",
+		}
+		getRouteMonitor = func() (v1alpha1.RouteMonitor, error) {
+			routeMonitor := v1alpha1.RouteMonitor{}
+			err := routeClient.Get(ctx, types.NamespacedName{Name: "route-checkout", Namespace: "shop"}, &routeMonitor)
+			return routeMonitor, err
+		}
+	})
+	JustBeforeEach(func() {
+		routeObject = routev1.Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "checkout",
+				Namespace:   "shop",
+				UID:         "fake-uid",
+				Annotations: routeAnnotations,
+			},
+		}
+		addObjects()
+		routeClient = fake.NewFakeClientWithScheme(constinit.Scheme, append(objects, routeObject.DeepCopy())...)
+		recorder = record.NewFakeRecorder(10)
+		routeReconciler = route.RouteReconciler{
+			Client:   routeClient,
+			Log:      constinit.Logger,
+			Scheme:   constinit.Scheme,
+			Recorder: recorder,
+		}
+	})
+
+	Describe("Reconcile", func() {
+		When("an annotation of the Route is invalid", func() {
+			BeforeEach(func() {
+				routeAnnotations[routemonitorconst.RouteIntervalAnnotation] = "often"
+			})
+			It("should skip the Route and report the annotations on it", func() {
+				// Act
+				res, err := routeReconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Name: "checkout", Namespace: "shop"}})
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{}))
+				_, err = getRouteMonitor()
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				Expect(recorder.Events).To(Receive(ContainSubstring("InvalidAnnotations")))
+			})
+		})
+	})
+
+	Describe("GetRoute", func() {
+		When("the Route is not found", func() {
+			It("should stop processing", func() {
+				// Act
+				_, res, err := routeReconciler.GetRoute(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "missing", Namespace: "shop"}})
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.StopOperation()))
+			})
+		})
+		When("the Route exists", func() {
+			It("should return it", func() {
+				// Act
+				resRoute, res, err := routeReconciler.GetRoute(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "checkout", Namespace: "shop"}})
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.ContinueOperation()))
+				Expect(resRoute.Name).To(Equal("checkout"))
+			})
+		})
+	})
+
+	Describe("IsMonitoringEnabled", func() {
+		When("the annotation is not 'true'", func() {
+			BeforeEach(func() {
+				routeAnnotations = map[string]string{routemonitorconst.RouteMonitoringEnabledAnnotation: "yes"}
+			})
+			It("should return false", func() {
+				// Act
+				res := route.IsMonitoringEnabled(routeObject)
+				// Assert
+				Expect(res).To(BeFalse())
+			})
+		})
+		When("the annotation is 'true'", func() {
+			It("should return true", func() {
+				// Act
+				res := route.IsMonitoringEnabled(routeObject)
+				// Assert
+				Expect(res).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("EnsureRouteMonitorExists", func() {
+		When("the RouteMonitor does not exist", func() {
+			BeforeEach(func() {
+				routeAnnotations[routemonitorconst.RouteIntervalAnnotation] = "1m"
+				routeAnnotations[routemonitorconst.RouteModuleAnnotation] = "tcp_connect"
+			})
+			It("should create it owned by the Route with the settings of the annotations", func() {
+				// Act
+				err := routeReconciler.EnsureRouteMonitorExists(ctx, routeObject)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				routeMonitor, err := getRouteMonitor()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metav1.IsControlledBy(&routeMonitor, &routeObject)).To(BeTrue())
+				Expect(routeMonitor.Spec.Route).To(Equal(v1alpha1.RouteMonitorRouteSpec{Name: "checkout", Namespace: "shop"}))
+				Expect(routeMonitor.Spec.Interval).To(Equal("1m"))
+				Expect(routeMonitor.Spec.Module).To(Equal("tcp_connect"))
+			})
+		})
+		When("the annotations changed", func() {
+			BeforeEach(func() {
+				routeAnnotations[routemonitorconst.RouteIntervalAnnotation] = "1m"
+				addObjects = func() {
+					routeMonitor := v1alpha1.RouteMonitor{
+						ObjectMeta: metav1.ObjectMeta{Name: "route-checkout", Namespace: "shop"},
+						Spec: v1alpha1.RouteMonitorSpec{
+							Route:    v1alpha1.RouteMonitorRouteSpec{Name: "checkout", Namespace: "shop"},
+							Interval: "5m",
+						},
+					}
+					Expect(controllerutil.SetControllerReference(&routeObject, &routeMonitor, constinit.Scheme)).To(Succeed())
+					objects = append(objects, &routeMonitor)
+				}
+			})
+			It("should update the RouteMonitor", func() {
+				// Act
+				err := routeReconciler.EnsureRouteMonitorExists(ctx, routeObject)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				routeMonitor, err := getRouteMonitor()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitor.Spec.Interval).To(Equal("1m"))
+			})
+		})
+		When("the module annotation names an unknown module", func() {
+			BeforeEach(func() {
+				routeAnnotations[routemonitorconst.RouteModuleAnnotation] = "not_a_module"
+			})
+			It("should return an Invalid CR error and not create the RouteMonitor", func() {
+				// Act
+				err := routeReconciler.EnsureRouteMonitorExists(ctx, routeObject)
+				// Assert
+				Expect(err).To(MatchError(customerrors.InvalidCR))
+				_, err = getRouteMonitor()
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+		When("a RouteMonitor with the same name is not owned by the Route", func() {
+			BeforeEach(func() {
+				objects = append(objects, &v1alpha1.RouteMonitor{
+					ObjectMeta: metav1.ObjectMeta{Name: "route-checkout", Namespace: "shop"},
+					Spec:       v1alpha1.RouteMonitorSpec{Interval: "5m"},
+				})
+			})
+			It("should leave it alone and return a Conflict error", func() {
+				// Act
+				err := routeReconciler.EnsureRouteMonitorExists(ctx, routeObject)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Conflict:"))
+				routeMonitor, err := getRouteMonitor()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitor.Spec.Interval).To(Equal("5m"))
+			})
+		})
+	})
+
+	Describe("EnsureRouteMonitorAbsent", func() {
+		When("the RouteMonitor is owned by the Route", func() {
+			BeforeEach(func() {
+				addObjects = func() {
+					routeMonitor := v1alpha1.RouteMonitor{
+						ObjectMeta: metav1.ObjectMeta{Name: "route-checkout", Namespace: "shop"},
+					}
+					Expect(controllerutil.SetControllerReference(&routeObject, &routeMonitor, constinit.Scheme)).To(Succeed())
+					objects = append(objects, &routeMonitor)
+				}
+			})
+			It("should delete it", func() {
+				// Act
+				err := routeReconciler.EnsureRouteMonitorAbsent(ctx, routeObject)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				_, err = getRouteMonitor()
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+		When("the RouteMonitor is not owned by the Route", func() {
+			BeforeEach(func() {
+				objects = append(objects, &v1alpha1.RouteMonitor{
+					ObjectMeta: metav1.ObjectMeta{Name: "route-checkout", Namespace: "shop"},
+				})
+			})
+			It("should leave it alone", func() {
+				// Act
+				err := routeReconciler.EnsureRouteMonitorAbsent(ctx, routeObject)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				_, err = getRouteMonitor()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("the RouteMonitor does not exist", func() {
+			It("should succeed", func() {
+				// Act
+				err := routeReconciler.EnsureRouteMonitorAbsent(ctx, routeObject)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
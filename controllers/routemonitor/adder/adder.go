@@ -4,6 +4,11 @@ import (
 	"github.com/go-logr/logr"
 
 	"context"
+	"fmt"
+	"net"
+	"path"
+	"reflect"
+	"strconv"
 
 	// k8s packages
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -15,6 +20,9 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	//local packages
@@ -22,6 +30,7 @@ import (
 	"github.com/openshift/route-monitor-operator/controllers/routemonitor"
 	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
 	"github.com/openshift/route-monitor-operator/pkg/const/blackbox"
+	"github.com/openshift/route-monitor-operator/pkg/util/blackboxconfig"
 	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
 	utilfinalizer "github.com/openshift/route-monitor-operator/pkg/util/finalizer"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
@@ -42,12 +51,67 @@ func New(r routemonitor.RouteMonitorReconciler) *RouteMonitorAdder {
 	}
 }
 
-func (r *RouteMonitorAdder) EnsureBlackBoxExporterDeploymentExists(ctx context.Context) error {
+func (r *RouteMonitorAdder) EnsureBlackBoxExporterConfigMapExists(ctx context.Context, config v1alpha1.RouteMonitorOperatorConfigSpec) error {
+	desired, err := r.desiredBlackBoxExporterConfigMap(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	resource := corev1.ConfigMap{}
+	// Does the resource already exist?
+	if err := r.Get(ctx, blackbox.BlackBoxNamespacedName, &resource); err != nil {
+		// If this is an unknown error
+		if !k8serrors.IsNotFound(err) {
+			// return unexpectedly
+			return err
+		}
+		// and create it
+		return r.Create(ctx, &desired)
+	}
+
+	// The modules in use changed, so the config has to follow
+	if reflect.DeepEqual(resource.Data, desired.Data) {
+		return nil
+	}
+	resource.Data = desired.Data
+	return r.Update(ctx, &resource)
+}
+
+func (r *RouteMonitorAdder) EnsureBlackBoxExporterServiceAccountExists(ctx context.Context) error {
+	resource := corev1.ServiceAccount{}
+	populationFunc := r.templateForBlackBoxExporterServiceAccount
+
+	// Does the resource already exist?
+	if err := r.Get(ctx, blackbox.BlackBoxNamespacedName, &resource); err != nil {
+		// If this is an unknown error
+		if !k8serrors.IsNotFound(err) {
+			// return unexpectedly
+			return err
+		}
+		// populate the resource with the template
+		resource := populationFunc()
+		// and create it
+		return r.Create(ctx, &resource)
+	}
+	return nil
+}
+
+func (r *RouteMonitorAdder) EnsureBlackBoxExporterDeploymentExists(ctx context.Context, config v1alpha1.RouteMonitorOperatorConfigSpec) error {
+	// The content of the ConfigMap decides if the exporter has to be rolled.
+	// It is rendered again rather than read back, as the cache may not have seen the ConfigMap created just before
+	configMap, err := r.desiredBlackBoxExporterConfigMap(ctx, config)
+	if err != nil {
+		return err
+	}
+	configHash := blackboxconfig.Hash(configMap.Data[blackbox.BlackBoxConfigKey])
+
 	resource := appsv1.Deployment{}
-	populationFunc := r.templateForBlackBoxExporterDeployment
+	populationFunc := func() appsv1.Deployment {
+		return r.templateForBlackBoxExporterDeployment(configHash, config.BlackBoxExporter)
+	}
 
 	// Does the resource already exist?
-	err := r.Get(ctx, blackbox.BlackBoxNamespacedName, &resource)
+	err = r.Get(ctx, blackbox.BlackBoxNamespacedName, &resource)
 	if err != nil {
 		// If this is an unknown error
 		if !k8serrors.IsNotFound(err) {
@@ -61,8 +125,17 @@ func (r *RouteMonitorAdder) EnsureBlackBoxExporterDeploymentExists(ctx context.C
 		if err != nil {
 			return err
 		}
+		return nil
 	}
-	return nil
+
+	// The fields set by the template are owned by the operator and put back when they drifted.
+	// As the template carries the hash of the config, this also rolls the exporter when its config changed
+	current := resource.DeepCopy()
+	mergeOwnedDeploymentFields(&resource, populationFunc())
+	if reflect.DeepEqual(current, &resource) {
+		return nil
+	}
+	return r.Update(ctx, &resource)
 }
 
 func (r *RouteMonitorAdder) EnsureBlackBoxExporterServiceExists(ctx context.Context) error {
@@ -79,33 +152,108 @@ func (r *RouteMonitorAdder) EnsureBlackBoxExporterServiceExists(ctx context.Cont
 		// populate the resource with the template
 		resource := populationFunc()
 		// and create it
-		if err = r.Create(ctx, &resource); err != nil {
+		return r.Create(ctx, &resource)
+	}
+
+	// The selector and ports are owned by the operator, fields like the clusterIP are left alone
+	current := resource.DeepCopy()
+	mergeOwnedServiceFields(&resource, populationFunc())
+	if reflect.DeepEqual(current, &resource) {
+		return nil
+	}
+	return r.Update(ctx, &resource)
+}
+
+func (r *RouteMonitorAdder) EnsureBlackBoxExporterPodDisruptionBudgetExists(ctx context.Context) error {
+	resource := policyv1beta1.PodDisruptionBudget{}
+	populationFunc := r.templateForBlackBoxExporterPodDisruptionBudget
+
+	// Does the resource already exist?
+	if err := r.Get(ctx, blackbox.BlackBoxNamespacedName, &resource); err != nil {
+		// If this is an unknown error
+		if !k8serrors.IsNotFound(err) {
+			// return unexpectedly
+			return err
+		}
+		// populate the resource with the template
+		resource := populationFunc()
+		// and create it
+		return r.Create(ctx, &resource)
+	}
+
+	desired := populationFunc()
+	labels := mergeLabels(resource.Labels, desired.Labels)
+	if reflect.DeepEqual(resource.Spec, desired.Spec) && reflect.DeepEqual(resource.Labels, labels) {
+		return nil
+	}
+	resource.Labels = labels
+	resource.Spec = desired.Spec
+	return r.Update(ctx, &resource)
+}
+
+func (r *RouteMonitorAdder) EnsureBlackBoxExporterNetworkPolicyExists(ctx context.Context, config v1alpha1.RouteMonitorOperatorConfigSpec) error {
+	resource := networkingv1.NetworkPolicy{}
+	populationFunc := func() networkingv1.NetworkPolicy {
+		return r.templateForBlackBoxExporterNetworkPolicy(config)
+	}
+
+	// Does the resource already exist?
+	if err := r.Get(ctx, blackbox.BlackBoxNamespacedName, &resource); err != nil {
+		// If this is an unknown error
+		if !k8serrors.IsNotFound(err) {
+			// return unexpectedly
 			return err
 		}
+		// populate the resource with the template
+		resource := populationFunc()
+		// and create it
+		return r.Create(ctx, &resource)
 	}
-	return nil
+
+	desired := populationFunc()
+	labels := mergeLabels(resource.Labels, desired.Labels)
+	if reflect.DeepEqual(resource.Spec, desired.Spec) && reflect.DeepEqual(resource.Labels, labels) {
+		return nil
+	}
+	resource.Labels = labels
+	resource.Spec = desired.Spec
+	return r.Update(ctx, &resource)
 }
 
-func (r *RouteMonitorAdder) EnsureServiceMonitorResourceExists(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
-	// Was the RouteURL populated by a previous step?
-	if routeMonitor.Status.RouteURL == "" {
+func (r *RouteMonitorAdder) EnsureServiceMonitorResourceExists(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) (utilreconcile.Result, error) {
+	// Were the RouteURLs populated by a previous step?
+	if len(routeMonitor.Status.RouteURLs) == 0 {
 		return utilreconcile.RequeueReconcileWith(customerrors.NoHost)
 	}
 
+	if err := routeMonitor.ValidateProbeTimings(); err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
+	}
+
+	if err := routeMonitor.ValidateModule(); err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
+	}
+
 	if !routeMonitor.HasFinalizer() {
-		// If the routeMonitor doesn't have a finalizer, add it
+		// If the routeMonitor doesn't have a finalizer, add it.
+		// It is patched, as the probe defaults applied in memory must not be stored in the spec
+		patch := client.MergeFrom(routeMonitor.DeepCopy())
 		utilfinalizer.Add(&routeMonitor, routemonitorconst.FinalizerKey)
-		if err := r.Update(ctx, &routeMonitor); err != nil {
+		if err := r.Patch(ctx, &routeMonitor, patch); err != nil {
 			return utilreconcile.RequeueReconcileWith(err)
 		}
 		return utilreconcile.StopReconcile()
 	}
 
+	if routeMonitor.GetBackend() == v1alpha1.BackendProbe {
+		return r.ensureProbeResourceExists(ctx, routeMonitor, config)
+	}
+
 	namespacedName := routeMonitor.TemplateForServiceMonitorName()
 
 	resource := &monitoringv1.ServiceMonitor{}
 	populationFunc := func() monitoringv1.ServiceMonitor {
-		return r.templateForServiceMonitorResource(routeMonitor)
+		return r.templateForServiceMonitorResource(routeMonitor, config)
 	}
 
 	// Does the resource already exist?
@@ -122,19 +270,247 @@ func (r *RouteMonitorAdder) EnsureServiceMonitorResourceExists(ctx context.Conte
 		if err != nil {
 			return utilreconcile.RequeueReconcileWith(err)
 		}
+		return utilreconcile.ContinueReconcile()
+	}
+
+	// The ServiceMonitor was created for another RouteMonitor or by someone else, it must not be taken over
+	if !routeMonitor.OwnsGeneratedResource(resource) {
+		return utilreconcile.RequeueReconcileWith(fmt.Errorf("%w: ServiceMonitor %s", customerrors.ForeignResource, namespacedName.String()))
 	}
 
 	return utilreconcile.ContinueReconcile()
 }
 
+// ensureProbeResourceExists creates the Probe of a RouteMonitor using the Probe backend, under the name of its ServiceMonitor
+func (r *RouteMonitorAdder) ensureProbeResourceExists(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) (utilreconcile.Result, error) {
+	// Probes cannot authenticate against kube-rbac-proxy, they would only fail to scrape
+	if config.BlackBoxExporter.KubeRBACProxy.Enabled {
+		return utilreconcile.RequeueReconcileWith(fmt.Errorf("%w: the Probe backend cannot scrape the exporter through kube-rbac-proxy, use the ServiceMonitor backend", customerrors.InvalidCR))
+	}
+
+	namespacedName := routeMonitor.TemplateForServiceMonitorName()
+
+	resource := &monitoringv1.Probe{}
+	populationFunc := func() monitoringv1.Probe {
+		return r.templateForProbeResource(routeMonitor, config)
+	}
+
+	// Does the resource already exist?
+	if err := r.Get(ctx, namespacedName, resource); err != nil {
+		// If this is an unknown error
+		if !k8serrors.IsNotFound(err) {
+			// return unexpectedly
+			return utilreconcile.RequeueReconcileWith(err)
+		}
+		// populate the resource with the template
+		resource := populationFunc()
+		// and create it
+		err = r.Create(ctx, &resource)
+		if err != nil {
+			return utilreconcile.RequeueReconcileWith(err)
+		}
+		return utilreconcile.ContinueReconcile()
+	}
+
+	// The Probe was created for another RouteMonitor or by someone else, it must not be taken over
+	if !routeMonitor.OwnsGeneratedResource(resource) {
+		return utilreconcile.RequeueReconcileWith(fmt.Errorf("%w: Probe %s", customerrors.ForeignResource, namespacedName.String()))
+	}
+
+	return utilreconcile.ContinueReconcile()
+}
+
+// EnsureServiceMonitorResourceUpToDate patches the ServiceMonitor when it differs from the template,
+// e.g. when the host of the Route, the probe settings or the configured labels changed. It returns whether the ServiceMonitor was patched.
+// RouteMonitors using the Probe backend get their Probe patched instead
+func (r *RouteMonitorAdder) EnsureServiceMonitorResourceUpToDate(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) (bool, error) {
+	if routeMonitor.GetBackend() == v1alpha1.BackendProbe {
+		return r.ensureProbeResourceUpToDate(ctx, routeMonitor, config)
+	}
+
+	resource := monitoringv1.ServiceMonitor{}
+	if err := r.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &resource); err != nil {
+		return false, err
+	}
+	if !routeMonitor.OwnsGeneratedResource(&resource) {
+		return false, fmt.Errorf("%w: ServiceMonitor %s/%s", customerrors.ForeignResource, resource.Namespace, resource.Name)
+	}
+
+	desired := r.templateForServiceMonitorResource(routeMonitor, config)
+	labels := mergeLabels(resource.Labels, desired.Labels)
+	if reflect.DeepEqual(resource.Spec, desired.Spec) && reflect.DeepEqual(resource.Labels, labels) {
+		return false, nil
+	}
+
+	r.Log.V(2).Info("ServiceMonitor mismatch: patching the ServiceMonitor to the template", "serviceMonitor", resource.Namespace+"/"+resource.Name)
+	patch := client.MergeFrom(resource.DeepCopy())
+	resource.Labels = labels
+	resource.Spec = desired.Spec
+	if err := r.Patch(ctx, &resource, patch); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ensureProbeResourceUpToDate patches the Probe when it differs from the template, like EnsureServiceMonitorResourceUpToDate
+func (r *RouteMonitorAdder) ensureProbeResourceUpToDate(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) (bool, error) {
+	resource := monitoringv1.Probe{}
+	if err := r.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &resource); err != nil {
+		return false, err
+	}
+	if !routeMonitor.OwnsGeneratedResource(&resource) {
+		return false, fmt.Errorf("%w: Probe %s/%s", customerrors.ForeignResource, resource.Namespace, resource.Name)
+	}
+
+	desired := r.templateForProbeResource(routeMonitor, config)
+	labels := mergeLabels(resource.Labels, desired.Labels)
+	if reflect.DeepEqual(resource.Spec, desired.Spec) && reflect.DeepEqual(resource.Labels, labels) {
+		return false, nil
+	}
+
+	r.Log.V(2).Info("Probe mismatch: patching the Probe to the template", "probe", resource.Namespace+"/"+resource.Name)
+	patch := client.MergeFrom(resource.DeepCopy())
+	resource.Labels = labels
+	resource.Spec = desired.Spec
+	if err := r.Patch(ctx, &resource, patch); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// EnsurePrometheusRuleResourceExists creates the PrometheusRule alerting on the failing probes of a RouteMonitor with alerting,
+// and patches it when it differs from the template. It does nothing for a RouteMonitor without alerting,
+// its PrometheusRule is deleted by EnsureStaleServiceMonitorResourcesAbsent of the deleter
+func (r *RouteMonitorAdder) EnsurePrometheusRuleResourceExists(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) error {
+	if routeMonitor.Spec.Alerting == nil {
+		return nil
+	}
+
+	desired := r.templateForPrometheusRuleResource(routeMonitor, config)
+
+	resource := monitoringv1.PrometheusRule{}
+	if err := r.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		return r.Create(ctx, &desired)
+	}
+	if !routeMonitor.OwnsGeneratedResource(&resource) {
+		return fmt.Errorf("%w: PrometheusRule %s/%s", customerrors.ForeignResource, resource.Namespace, resource.Name)
+	}
+
+	labels := mergeLabels(resource.Labels, desired.Labels)
+	if reflect.DeepEqual(resource.Spec, desired.Spec) && reflect.DeepEqual(resource.Labels, labels) {
+		return nil
+	}
+
+	r.Log.V(2).Info("PrometheusRule mismatch: patching the PrometheusRule to the template", "prometheusRule", resource.Namespace+"/"+resource.Name)
+	patch := client.MergeFrom(resource.DeepCopy())
+	resource.Labels = labels
+	resource.Spec = desired.Spec
+	return r.Patch(ctx, &resource, patch)
+}
+
+// desiredBlackBoxExporterConfigMap renders the ConfigMap for the RouteMonitors of the cluster, with the probe defaults of the config applied
+func (r *RouteMonitorAdder) desiredBlackBoxExporterConfigMap(ctx context.Context, config v1alpha1.RouteMonitorOperatorConfigSpec) (corev1.ConfigMap, error) {
+	routeMonitors := &v1alpha1.RouteMonitorList{}
+	if err := r.List(ctx, routeMonitors); err != nil {
+		return corev1.ConfigMap{}, err
+	}
+	for i := range routeMonitors.Items {
+		routeMonitors.Items[i].ApplyProbeDefaults(config.ProbeDefaults)
+	}
+	return r.templateForBlackBoxExporterConfigMap(routeMonitors.Items)
+}
+
+// templateForBlackBoxExporterConfigMap returns the blackbox config holding every module used by the RouteMonitors
+func (r *RouteMonitorAdder) templateForBlackBoxExporterConfigMap(routeMonitors []v1alpha1.RouteMonitor) (corev1.ConfigMap, error) {
+	// The default module is always present, so the exporter has a valid config even without RouteMonitors
+	config, _ := blackboxconfig.New(blackbox.DefaultModule)
+	for _, routeMonitor := range routeMonitors {
+		if routeMonitor.WasDeleteRequested() {
+			continue
+		}
+		module, err := routeMonitor.TemplateForModule()
+		if err != nil {
+			r.Log.V(1).Info("Invalid module: skipping module of RouteMonitor", "routeMonitor", routeMonitor.Namespace+"/"+routeMonitor.Name, "error", err.Error())
+			continue
+		}
+		config.AddModule(routeMonitor.TemplateForModuleName(), module)
+	}
+
+	renderedConfig, err := config.Render()
+	if err != nil {
+		return corev1.ConfigMap{}, err
+	}
+
+	configMap := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      blackbox.BlackBoxName,
+			Namespace: blackbox.BlackBoxNamespace,
+			Labels:    blackbox.GenerateBlackBoxLables(),
+		},
+		Data: map[string]string{
+			blackbox.BlackBoxConfigKey: renderedConfig,
+		},
+	}
+	return configMap, nil
+}
+
 // deploymentForBlackBoxExporter returns a blackbox deployment
-func (*RouteMonitorAdder) templateForBlackBoxExporterDeployment() appsv1.Deployment {
+func (*RouteMonitorAdder) templateForBlackBoxExporterDeployment(configHash string, config v1alpha1.BlackBoxExporterConfig) appsv1.Deployment {
 	labels := blackbox.GenerateBlackBoxLables()
 	labelSelectors := metav1.LabelSelector{
 		MatchLabels: labels}
-	// hardcode the replicasize for no
-	//replicas := m.Spec.Size
-	var replicas int32 = 1
+
+	image := blackbox.BlackBoxImage
+	if config.Image != "" {
+		image = config.Image
+	}
+	replicas := blackbox.DefaultBlackBoxReplicas
+	if config.Replicas != nil {
+		replicas = *config.Replicas
+	}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+			corev1.ResourceMemory: resource.MustParse("32Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+	}
+	if config.Resources != nil {
+		resources = *config.Resources
+	}
+	// The user is left to the cluster, e.g. OpenShift assigns one from the range of the namespace
+	runAsNonRoot := true
+	readOnlyRootFilesystem := true
+	allowPrivilegeEscalation := false
+	// The containers only need to open connections, they write nothing and need no privileges
+	securityContext := &corev1.SecurityContext{
+		RunAsNonRoot:             &runAsNonRoot,
+		ReadOnlyRootFilesystem:   &readOnlyRootFilesystem,
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+	}
+
+	// All fields the API server defaults are set, so the probes of a live deployment match the template
+	healthProbe := &corev1.Probe{
+		Handler: corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   blackbox.BlackBoxHealthPath,
+				Port:   intstr.FromString(blackbox.BlackBoxPortName),
+				Scheme: corev1.URISchemeHTTP,
+			},
+		},
+		TimeoutSeconds:   1,
+		PeriodSeconds:    10,
+		SuccessThreshold: 1,
+		FailureThreshold: 3,
+	}
 
 	dep := appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -148,23 +524,144 @@ func (*RouteMonitorAdder) templateForBlackBoxExporterDeployment() appsv1.Deploym
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: labels,
+					Annotations: map[string]string{
+						blackbox.BlackBoxConfigHashAnnotation: configHash,
+					},
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{{
-						Image: "prom/blackbox-exporter:master",
-						Name:  "blackbox-exporter",
+						Image:           image,
+						Name:            "blackbox-exporter",
+						Resources:       resources,
+						LivenessProbe:   healthProbe,
+						ReadinessProbe:  healthProbe.DeepCopy(),
+						SecurityContext: securityContext,
+						Args: []string{
+							"--config.file=" + path.Join(blackbox.BlackBoxConfigMountPath, blackbox.BlackBoxConfigKey),
+						},
 						Ports: []corev1.ContainerPort{{
 							ContainerPort: blackbox.BlackBoxPortNumber,
 							Name:          blackbox.BlackBoxPortName,
+							Protocol:      corev1.ProtocolTCP,
+						}},
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "config",
+							MountPath: blackbox.BlackBoxConfigMountPath,
+							ReadOnly:  true,
 						}},
 					}},
+					Volumes: []corev1.Volume{{
+						Name: "config",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: blackbox.BlackBoxName,
+								},
+							},
+						},
+					}},
+					// The exporters are spread over nodes and zones, so a drain or an outage only takes out some of them
+					Affinity: &corev1.Affinity{
+						PodAntiAffinity: &corev1.PodAntiAffinity{
+							PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+								Weight: 100,
+								PodAffinityTerm: corev1.PodAffinityTerm{
+									LabelSelector: &labelSelectors,
+									TopologyKey:   corev1.LabelHostname,
+								},
+							}},
+						},
+					},
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+						MaxSkew:           1,
+						TopologyKey:       corev1.LabelZoneFailureDomainStable,
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+						LabelSelector:     &labelSelectors,
+					}},
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: &runAsNonRoot,
+					},
+					ServiceAccountName: blackbox.BlackBoxName,
+					NodeSelector:       config.NodeSelector,
+					Tolerations:        config.Tolerations,
+					ImagePullSecrets:   config.ImagePullSecrets,
 				},
 			},
 		},
 	}
+	if config.KubeRBACProxy.Enabled {
+		addKubeRBACProxy(&dep.Spec.Template.Spec, config.KubeRBACProxy, securityContext)
+	}
 	return dep
 }
 
+// addKubeRBACProxy moves the exporter to localhost and lets kube-rbac-proxy serve its port with TLS,
+// so every probe has to be authorized. The health checks are let through without authorization
+func addKubeRBACProxy(podSpec *corev1.PodSpec, config v1alpha1.KubeRBACProxyConfig, securityContext *corev1.SecurityContext) {
+	image := blackbox.KubeRBACProxyImage
+	if config.Image != "" {
+		image = config.Image
+	}
+
+	exporter := &podSpec.Containers[0]
+	exporter.Args = append(exporter.Args, fmt.Sprintf("--web.listen-address=127.0.0.1:%d", blackbox.BlackBoxUpstreamPortNumber))
+	ports := exporter.Ports
+	exporter.Ports = nil
+	// The kubelet reaches the health path through the proxy,
+	// by number as named ports are only looked up in the probed container
+	for _, probe := range []*corev1.Probe{exporter.LivenessProbe, exporter.ReadinessProbe} {
+		probe.HTTPGet.Port = intstr.FromInt(blackbox.BlackBoxPortNumber)
+		probe.HTTPGet.Scheme = corev1.URISchemeHTTPS
+	}
+
+	podSpec.Containers = append(podSpec.Containers, corev1.Container{
+		Name:  blackbox.KubeRBACProxyName,
+		Image: image,
+		Args: []string{
+			fmt.Sprintf("--secure-listen-address=0.0.0.0:%d", blackbox.BlackBoxPortNumber),
+			fmt.Sprintf("--upstream=http://127.0.0.1:%d/", blackbox.BlackBoxUpstreamPortNumber),
+			"--tls-cert-file=" + path.Join(blackbox.BlackBoxTLSMountPath, corev1.TLSCertKey),
+			"--tls-private-key-file=" + path.Join(blackbox.BlackBoxTLSMountPath, corev1.TLSPrivateKeyKey),
+			"--ignore-paths=" + blackbox.BlackBoxHealthPath,
+			"--logtostderr=true",
+		},
+		Ports: ports,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+				corev1.ResourceMemory: resource.MustParse("20Mi"),
+			},
+		},
+		SecurityContext: securityContext,
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      "tls",
+			MountPath: blackbox.BlackBoxTLSMountPath,
+			ReadOnly:  true,
+		}},
+	})
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: blackbox.BlackBoxTLSSecretName,
+			},
+		},
+	})
+}
+
+// templateForBlackBoxExporterServiceAccount returns the ServiceAccount of the blackbox deployment,
+// kube-rbac-proxy uses it to review the tokens of the clients
+func (*RouteMonitorAdder) templateForBlackBoxExporterServiceAccount() corev1.ServiceAccount {
+	serviceAccount := corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      blackbox.BlackBoxName,
+			Namespace: blackbox.BlackBoxNamespace,
+			Labels:    blackbox.GenerateBlackBoxLables(),
+		},
+	}
+	return serviceAccount
+}
+
 // templateForBlackBoxExporterService returns a blackbox service
 func (*RouteMonitorAdder) templateForBlackBoxExporterService() corev1.Service {
 	labels := blackbox.GenerateBlackBoxLables()
@@ -174,6 +671,10 @@ func (*RouteMonitorAdder) templateForBlackBoxExporterService() corev1.Service {
 			Name:      blackbox.BlackBoxName,
 			Namespace: blackbox.BlackBoxNamespace,
 			Labels:    labels,
+			// The certificate is only used by kube-rbac-proxy, issuing it anyway lets the proxy be enabled without waiting for it
+			Annotations: map[string]string{
+				blackbox.ServingCertSecretAnnotation: blackbox.BlackBoxTLSSecretName,
+			},
 		},
 		Spec: corev1.ServiceSpec{
 			Selector: labels,
@@ -181,59 +682,404 @@ func (*RouteMonitorAdder) templateForBlackBoxExporterService() corev1.Service {
 				TargetPort: intstr.FromString(blackbox.BlackBoxPortName),
 				Port:       blackbox.BlackBoxPortNumber,
 				Name:       blackbox.BlackBoxPortName,
+				Protocol:   corev1.ProtocolTCP,
 			}},
 		},
 	}
 	return svc
 }
 
+// templateForBlackBoxExporterPodDisruptionBudget returns a PodDisruptionBudget for the blackbox deployment.
+// It allows one exporter to be evicted at a time, so a single exporter doesn't block draining its node
+func (*RouteMonitorAdder) templateForBlackBoxExporterPodDisruptionBudget() policyv1beta1.PodDisruptionBudget {
+	labels := blackbox.GenerateBlackBoxLables()
+	maxUnavailable := intstr.FromInt(1)
+
+	pdb := policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      blackbox.BlackBoxName,
+			Namespace: blackbox.BlackBoxNamespace,
+			Labels:    labels,
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector:       &metav1.LabelSelector{MatchLabels: labels},
+		},
+	}
+	return pdb
+}
+
+// templateForBlackBoxExporterNetworkPolicy returns a NetworkPolicy that only lets Prometheus pods of the monitoring namespaces reach the exporter,
+// as the exporter probes whatever target it is asked for
+func (*RouteMonitorAdder) templateForBlackBoxExporterNetworkPolicy(config v1alpha1.RouteMonitorOperatorConfigSpec) networkingv1.NetworkPolicy {
+	labels := blackbox.GenerateBlackBoxLables()
+	protocol := corev1.ProtocolTCP
+	port := intstr.FromString(blackbox.BlackBoxPortName)
+	namespaceSelector := &metav1.LabelSelector{MatchLabels: map[string]string{blackbox.ClusterMonitoringNamespaceLabel: "true"}}
+	if config.BlackBoxExporter.PrometheusNamespaceSelector != nil {
+		namespaceSelector = config.BlackBoxExporter.PrometheusNamespaceSelector
+	}
+
+	networkPolicy := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      blackbox.BlackBoxName,
+			Namespace: blackbox.BlackBoxNamespace,
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: labels},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				Ports: []networkingv1.NetworkPolicyPort{{
+					Protocol: &protocol,
+					Port:     &port,
+				}},
+				From: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: namespaceSelector,
+					PodSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      blackbox.PrometheusPodLabel,
+							Operator: metav1.LabelSelectorOpExists,
+						}},
+					},
+				}},
+			}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+	return networkPolicy
+}
+
 // templateForServiceMonitorResource returns a ServiceMonitor
-func (r *RouteMonitorAdder) templateForServiceMonitorResource(routeMonitor v1alpha1.RouteMonitor) monitoringv1.ServiceMonitor {
+func (r *RouteMonitorAdder) templateForServiceMonitorResource(routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) monitoringv1.ServiceMonitor {
 
-	routeURL := routeMonitor.Status.RouteURL
-	serviceMonitorName := routeMonitor.TemplateForServiceMonitorName().Name
+	serviceMonitorNamespacedName := routeMonitor.TemplateForServiceMonitorName()
+	serviceMonitorName := serviceMonitorNamespacedName.Name
 
 	routeMonitorLabels := blackbox.GenerateBlackBoxLables()
 
 	labelSelector := metav1.LabelSelector{MatchLabels: routeMonitorLabels}
 
-	// Currently we only support `http_2xx` as module
-	// Still make it a variable so we can easily add functionality later
-	modules := []string{"http_2xx"}
+	// Every admitted ingress of every Route is probed by its own endpoint, as an endpoint can only pass a single target
+	endpoints := []monitoringv1.Endpoint{}
+	for _, routeURL := range routeMonitor.Status.RouteURLs {
+		params := map[string][]string{
+			"module": {routeMonitor.TemplateForModuleName()},
+			"target": {templateForProbeTarget(routeMonitor, routeURL)},
+		}
+		endpoint := monitoringv1.Endpoint{
+			Port:     blackbox.BlackBoxPortName,
+			Interval: routeMonitor.GetInterval(),
+			// Timeout has to be smaller than probe interval
+			ScrapeTimeout: routeMonitor.GetScrapeTimeout(),
+			Path:          "/probe",
+			Scheme:        "http",
+			Params:        params,
+			MetricRelabelConfigs: []*monitoringv1.RelabelConfig{
+				{
+					Replacement: routeURL.URL,
+					TargetLabel: "RouteMonitorUrl",
+				},
+				{
+					Replacement: routeURL.RouterName,
+					TargetLabel: "RouteMonitorRouter",
+				},
+				{
+					Replacement: routeURL.RouteName,
+					TargetLabel: "RouteMonitorRoute",
+				},
+				// The RouteMonitor selects its own probes by these, e.g. in its alert, as others may probe the same urls
+				{
+					Replacement: routeMonitor.Namespace,
+					TargetLabel: "RouteMonitorNamespace",
+				},
+				{
+					Replacement: routeMonitor.Name,
+					TargetLabel: "RouteMonitorName",
+				},
+			},
+		}
+		if config.BlackBoxExporter.KubeRBACProxy.Enabled {
+			endpoint.Scheme = "https"
+			endpoint.BearerTokenFile = blackbox.ServiceAccountTokenFile
+			endpoint.TLSConfig = templateForKubeRBACProxyTLSConfig(config.BlackBoxExporter.KubeRBACProxy)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
 
-	params := map[string][]string{
-		"module": modules,
-		"target": {routeURL},
+	// A ServiceMonitor next to its RouteMonitor has to look for the exporter Service in the namespace of the exporter
+	namespaceSelector := monitoringv1.NamespaceSelector{}
+	if serviceMonitorNamespacedName.Namespace != blackbox.BlackBoxNamespace {
+		namespaceSelector.MatchNames = []string{blackbox.BlackBoxNamespace}
 	}
 
 	serviceMonitor := monitoringv1.ServiceMonitor{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: serviceMonitorName,
-			// ServiceMonitors need to be in `openshift-monitoring` to be picked up by cluster-monitoring-operator
-			Namespace: blackbox.BlackBoxNamespace,
+			// ServiceMonitors need to be next to the exporter to be picked up by cluster-monitoring-operator,
+			// unless they are placed next to the RouteMonitors for another Prometheus
+			Namespace: serviceMonitorNamespacedName.Namespace,
+			// The owner label is applied last, so the configured labels cannot hide the owner of the ServiceMonitor
+			Labels:      mergeLabels(config.ServiceMonitor.Labels, routeMonitor.TemplateForServiceMonitorOwnerLabels()),
+			Annotations: routeMonitor.TemplateForServiceMonitorOwnerAnnotations(),
 		},
 		Spec: monitoringv1.ServiceMonitorSpec{
-			JobLabel: serviceMonitorName,
-			Endpoints: []monitoringv1.Endpoint{
+			JobLabel:          serviceMonitorName,
+			Endpoints:         endpoints,
+			Selector:          labelSelector,
+			NamespaceSelector: namespaceSelector,
+		},
+	}
+	return serviceMonitor
+}
+
+// templateForProbeResource returns a Probe, which passes every url of the RouteMonitor to the exporter as a static target.
+// Prometheus sets the target as the instance label, so the probes can be queried by url
+func (r *RouteMonitorAdder) templateForProbeResource(routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) monitoringv1.Probe {
+	namespacedName := routeMonitor.TemplateForServiceMonitorName()
+
+	targets := []string{}
+	for _, routeURL := range routeMonitor.Status.RouteURLs {
+		targets = append(targets, templateForProbeTarget(routeMonitor, routeURL))
+	}
+
+	probe := monitoringv1.Probe{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespacedName.Name,
+			Namespace: namespacedName.Namespace,
+			// The configured labels of the ServiceMonitors apply as well, as a Prometheus usually selects both kinds alike
+			Labels:      mergeLabels(config.ServiceMonitor.Labels, routeMonitor.TemplateForServiceMonitorOwnerLabels()),
+			Annotations: routeMonitor.TemplateForServiceMonitorOwnerAnnotations(),
+		},
+		Spec: monitoringv1.ProbeSpec{
+			JobName: namespacedName.Name,
+			ProberSpec: monitoringv1.ProberSpec{
+				URL:    fmt.Sprintf("%s.%s.svc:%d", blackbox.BlackBoxName, blackbox.BlackBoxNamespace, blackbox.BlackBoxPortNumber),
+				Scheme: "http",
+				Path:   "/probe",
+			},
+			Module: routeMonitor.TemplateForModuleName(),
+			Targets: monitoringv1.ProbeTargets{
+				StaticConfig: &monitoringv1.ProbeTargetStaticConfig{
+					Targets: targets,
+				},
+			},
+			Interval: routeMonitor.GetInterval(),
+			// Timeout has to be smaller than probe interval
+			ScrapeTimeout: routeMonitor.GetScrapeTimeout(),
+		},
+	}
+	return probe
+}
+
+// templateForPrometheusRuleResource returns a PrometheusRule with a single alert, firing when a probe of the RouteMonitor fails
+func (r *RouteMonitorAdder) templateForPrometheusRuleResource(routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) monitoringv1.PrometheusRule {
+	namespacedName := routeMonitor.TemplateForServiceMonitorName()
+	alerting := *routeMonitor.Spec.Alerting
+
+	// The severity is applied last, so the labels cannot hide it
+	labels := mergeLabels(alerting.Labels, map[string]string{"severity": alerting.GetSeverity()})
+
+	prometheusRule := monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespacedName.Name,
+			Namespace: namespacedName.Namespace,
+			// The configured labels of the ServiceMonitors apply as well, as a Prometheus usually selects its rules alike
+			Labels:      mergeLabels(config.ServiceMonitor.Labels, routeMonitor.TemplateForServiceMonitorOwnerLabels()),
+			Annotations: routeMonitor.TemplateForServiceMonitorOwnerAnnotations(),
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
 				{
-					Port: blackbox.BlackBoxPortName,
-					// Probe every 30s
-					Interval: "30s",
-					// Timeout has to be smaller than probe interval
-					ScrapeTimeout: "15s",
-					Path:          "/probe",
-					Scheme:        "http",
-					Params:        params,
-					MetricRelabelConfigs: []*monitoringv1.RelabelConfig{
+					Name: namespacedName.Name,
+					Rules: []monitoringv1.Rule{
 						{
-							Replacement: routeURL,
-							TargetLabel: "RouteMonitorUrl",
+							Alert:  blackbox.ProbeFailingAlertName,
+							Expr:   intstr.FromString(templateForProbeFailingExpr(routeMonitor)),
+							For:    alerting.GetFor(),
+							Labels: labels,
+							Annotations: map[string]string{
+								"summary":     fmt.Sprintf("RouteMonitor %s/%s is failing", routeMonitor.Namespace, routeMonitor.Name),
+								"description": fmt.Sprintf("The probe of {{ $labels.instance }} by RouteMonitor %s/%s has been failing for %s.", routeMonitor.Namespace, routeMonitor.Name, alerting.GetFor()),
+							},
 						},
 					},
-				}},
-			Selector:          labelSelector,
-			NamespaceSelector: monitoringv1.NamespaceSelector{},
+				},
+			},
 		},
 	}
-	return serviceMonitor
+	return prometheusRule
+}
+
+// templateForProbeFailingExpr selects the failed probes of the RouteMonitor.
+// Probes carry their job name, the ServiceMonitor endpoints share the job of the exporter and are labelled with the RouteMonitor instead
+func templateForProbeFailingExpr(routeMonitor v1alpha1.RouteMonitor) string {
+	if routeMonitor.GetBackend() == v1alpha1.BackendProbe {
+		return fmt.Sprintf("probe_success{job=%s} == 0", strconv.Quote(routeMonitor.TemplateForServiceMonitorName().Name))
+	}
+	return fmt.Sprintf("probe_success{RouteMonitorNamespace=%s,RouteMonitorName=%s} == 0",
+		strconv.Quote(routeMonitor.Namespace), strconv.Quote(routeMonitor.Name))
+}
+
+// templateForKubeRBACProxyTLSConfig verifies the certificate kube-rbac-proxy serves for the exporter Service
+func templateForKubeRBACProxyTLSConfig(config v1alpha1.KubeRBACProxyConfig) *monitoringv1.TLSConfig {
+	caFile := blackbox.DefaultServingCAFile
+	if config.CAFile != "" {
+		caFile = config.CAFile
+	}
+	return &monitoringv1.TLSConfig{
+		CAFile:     caFile,
+		ServerName: fmt.Sprintf("%s.%s.svc", blackbox.BlackBoxName, blackbox.BlackBoxNamespace),
+	}
+}
+
+// templateForProbeTarget returns what the blackbox exporter probes:
+// the full url for http modules, or host and port as tcp modules only open a connection
+func templateForProbeTarget(routeMonitor v1alpha1.RouteMonitor, routeURL v1alpha1.RouteMonitorURL) string {
+	module, err := routeMonitor.TemplateForModule()
+	if err != nil || module.Prober != "tcp" {
+		return routeURL.URL
+	}
+	port := "80"
+	if routeURL.Scheme == "https" {
+		port = "443"
+	}
+	return net.JoinHostPort(routeURL.Host, port)
+}
+
+// mergeOwnedDeploymentFields copies the fields of the template onto the deployment, keeping the fields the template doesn't set
+func mergeOwnedDeploymentFields(resource *appsv1.Deployment, desired appsv1.Deployment) {
+	resource.Labels = mergeLabels(resource.Labels, desired.Labels)
+	resource.Spec.Replicas = desired.Spec.Replicas
+	resource.Spec.Template.Labels = mergeLabels(resource.Spec.Template.Labels, desired.Spec.Template.Labels)
+	resource.Spec.Template.Annotations = mergeLabels(resource.Spec.Template.Annotations, desired.Spec.Template.Annotations)
+
+	podSpec := &resource.Spec.Template.Spec
+	// The scheduling constraints and pull secrets are only owned once they are configured, otherwise the ones set by others are kept
+	if desired.Spec.Template.Spec.NodeSelector != nil {
+		podSpec.NodeSelector = desired.Spec.Template.Spec.NodeSelector
+	}
+	if desired.Spec.Template.Spec.Tolerations != nil {
+		podSpec.Tolerations = desired.Spec.Template.Spec.Tolerations
+	}
+	if desired.Spec.Template.Spec.ImagePullSecrets != nil {
+		podSpec.ImagePullSecrets = desired.Spec.Template.Spec.ImagePullSecrets
+	}
+	podSpec.Affinity = desired.Spec.Template.Spec.Affinity
+	podSpec.SecurityContext = desired.Spec.Template.Spec.SecurityContext
+	podSpec.TopologySpreadConstraints = desired.Spec.Template.Spec.TopologySpreadConstraints
+	podSpec.ServiceAccountName = desired.Spec.Template.Spec.ServiceAccountName
+	// The API server mirrors the name into the deprecated field, a stale one would win over the desired name
+	if podSpec.DeprecatedServiceAccount != "" {
+		podSpec.DeprecatedServiceAccount = desired.Spec.Template.Spec.ServiceAccountName
+	}
+	for _, container := range desired.Spec.Template.Spec.Containers {
+		mergeOwnedContainerFields(podSpec, container)
+	}
+	for _, volume := range desired.Spec.Template.Spec.Volumes {
+		mergeOwnedVolume(podSpec, volume)
+	}
+	// kube-rbac-proxy and its certificate are removed again once the proxy was disabled
+	if !hasContainer(desired.Spec.Template.Spec, blackbox.KubeRBACProxyName) {
+		removeContainer(podSpec, blackbox.KubeRBACProxyName)
+		removeVolume(podSpec, "tls")
+	}
+}
+
+func hasContainer(podSpec corev1.PodSpec, name string) bool {
+	for _, container := range podSpec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func removeContainer(podSpec *corev1.PodSpec, name string) {
+	for i, container := range podSpec.Containers {
+		if container.Name == name {
+			podSpec.Containers = append(podSpec.Containers[:i], podSpec.Containers[i+1:]...)
+			return
+		}
+	}
+}
+
+func removeVolume(podSpec *corev1.PodSpec, name string) {
+	for i, volume := range podSpec.Volumes {
+		if volume.Name == name {
+			podSpec.Volumes = append(podSpec.Volumes[:i], podSpec.Volumes[i+1:]...)
+			return
+		}
+	}
+}
+
+// mergeOwnedContainerFields puts back the image, args, ports, mounts, resources, probes and security context of the container,
+// or adds it when it is missing
+func mergeOwnedContainerFields(podSpec *corev1.PodSpec, desired corev1.Container) {
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		if container.Name != desired.Name {
+			continue
+		}
+		container.Image = desired.Image
+		container.Args = desired.Args
+		container.Ports = desired.Ports
+		container.VolumeMounts = desired.VolumeMounts
+		container.Resources = desired.Resources
+		container.LivenessProbe = desired.LivenessProbe
+		container.ReadinessProbe = desired.ReadinessProbe
+		container.SecurityContext = desired.SecurityContext
+		return
+	}
+	podSpec.Containers = append(podSpec.Containers, desired)
+}
+
+// mergeOwnedVolume puts back the source of the volume, or adds it when it is missing
+func mergeOwnedVolume(podSpec *corev1.PodSpec, desired corev1.Volume) {
+	for i := range podSpec.Volumes {
+		volume := &podSpec.Volumes[i]
+		if volume.Name != desired.Name {
+			continue
+		}
+		if !volumeSourceMatches(volume.VolumeSource, desired.VolumeSource) {
+			volume.VolumeSource = desired.VolumeSource
+		}
+		return
+	}
+	podSpec.Volumes = append(podSpec.Volumes, desired)
+}
+
+// volumeSourceMatches ignores the mode the API server defaults on ConfigMap and Secret volumes
+func volumeSourceMatches(current, desired corev1.VolumeSource) bool {
+	if current.ConfigMap != nil && desired.ConfigMap != nil {
+		return current.ConfigMap.Name == desired.ConfigMap.Name &&
+			reflect.DeepEqual(current.ConfigMap.Items, desired.ConfigMap.Items)
+	}
+	if current.Secret != nil && desired.Secret != nil {
+		return current.Secret.SecretName == desired.Secret.SecretName &&
+			reflect.DeepEqual(current.Secret.Items, desired.Secret.Items)
+	}
+	return reflect.DeepEqual(current, desired)
+}
+
+// mergeOwnedServiceFields copies the annotations, selector and ports of the template onto the service
+func mergeOwnedServiceFields(resource *corev1.Service, desired corev1.Service) {
+	resource.Labels = mergeLabels(resource.Labels, desired.Labels)
+	resource.Annotations = mergeLabels(resource.Annotations, desired.Annotations)
+	resource.Spec.Selector = desired.Spec.Selector
+	resource.Spec.Ports = desired.Spec.Ports
+}
+
+// mergeLabels adds the desired entries to the current ones, entries of others are kept
+func mergeLabels(current, desired map[string]string) map[string]string {
+	if len(desired) == 0 {
+		return current
+	}
+	merged := make(map[string]string, len(current)+len(desired))
+	for key, value := range current {
+		merged[key] = value
+	}
+	for key, value := range desired {
+		merged[key] = value
+	}
+	return merged
 }
@@ -1,6 +1,8 @@
 package adder_test
 
 import (
+	"errors"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -15,14 +17,25 @@ import (
 
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
 	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
+	"github.com/openshift/route-monitor-operator/pkg/const/blackbox"
 	consterror "github.com/openshift/route-monitor-operator/pkg/const/test/error"
 	constinit "github.com/openshift/route-monitor-operator/pkg/const/test/init"
+	"github.com/openshift/route-monitor-operator/pkg/util/blackboxconfig"
 	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 	clientmocks "github.com/openshift/route-monitor-operator/pkg/util/test/generated/mocks/client"
 	"github.com/openshift/route-monitor-operator/pkg/util/test/helper"
 	testhelper "github.com/openshift/route-monitor-operator/pkg/util/test/helper"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/openshift/route-monitor-operator/controllers/routemonitor"
 )
@@ -41,13 +54,16 @@ var _ = Describe("Adder", func() {
 		ctx context.Context
 
 		routeMonitor           v1alpha1.RouteMonitor
+		routeMonitorSpec       v1alpha1.RouteMonitorSpec
 		routeMonitorStatus     v1alpha1.RouteMonitorStatus
 		routeMonitorFinalizers []string
+		operatorConfig         v1alpha1.RouteMonitorOperatorConfigSpec
 
 		get    testhelper.MockHelper
 		delete testhelper.MockHelper
 		create testhelper.MockHelper
 		update testhelper.MockHelper
+		patch  testhelper.MockHelper
 	)
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
@@ -61,12 +77,15 @@ var _ = Describe("Adder", func() {
 		delete = testhelper.MockHelper{}
 		create = testhelper.MockHelper{}
 		update = testhelper.MockHelper{}
+		patch = testhelper.MockHelper{}
 
 		routeMonitorAdderClient = mockClient
+		routeMonitorSpec = v1alpha1.RouteMonitorSpec{}
 		routeMonitorStatus = v1alpha1.RouteMonitorStatus{
-			RouteURL: "fake-route-url",
+			RouteURLs: []v1alpha1.RouteMonitorURL{{URL: "fake-route-url"}},
 		}
 		routeMonitorFinalizers = routemonitorconst.FinalizerList
+		operatorConfig = v1alpha1.RouteMonitorOperatorConfigSpec{}
 
 	})
 	JustBeforeEach(func() {
@@ -80,6 +99,10 @@ var _ = Describe("Adder", func() {
 			Return(update.ErrorResponse).
 			Times(update.CalledTimes)
 
+		mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(patch.ErrorResponse).
+			Times(patch.CalledTimes)
+
 		mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(get.ErrorResponse).
 			Times(get.CalledTimes)
@@ -96,6 +119,7 @@ var _ = Describe("Adder", func() {
 			ObjectMeta: metav1.ObjectMeta{
 				Finalizers: routeMonitorFinalizers,
 			},
+			Spec:   routeMonitorSpec,
 			Status: routeMonitorStatus,
 		}
 	})
@@ -112,16 +136,35 @@ var _ = Describe("Adder", func() {
 		When("the resource Exists", func() {
 			BeforeEach(func() {
 				// Arrange
-				get.ErrorResponse = nil
+				get.CalledTimes = 0
 				create.CalledTimes = 0
 			})
+			JustBeforeEach(func() {
+				owned := monitoringv1.ServiceMonitor{
+					ObjectMeta: metav1.ObjectMeta{Labels: routeMonitor.TemplateForServiceMonitorOwnerLabels()},
+				}
+				mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, owned).Return(nil)
+			})
 			It("should call `Get` and not call `Create`", func() {
 				//Act
-				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor)
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
 				//Assert
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
+		When("the resource Exists but belongs to another RouteMonitor", func() {
+			BeforeEach(func() {
+				// Arrange
+				get.ErrorResponse = nil
+				create.CalledTimes = 0
+			})
+			It("should return an error and leave the resource alone", func() {
+				//Act
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+				//Assert
+				Expect(errors.Is(err, customerrors.ForeignResource)).To(BeTrue())
+			})
+		})
 		When("the resource Get fails unexpectedly", func() {
 			// Arrange
 			BeforeEach(func() {
@@ -130,7 +173,7 @@ var _ = Describe("Adder", func() {
 			})
 			It("should return the error and not call `Create`", func() {
 				//Act
-				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor)
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
 				//Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.CustomError))
@@ -141,7 +184,7 @@ var _ = Describe("Adder", func() {
 			// Arrange
 			It("should call `Get` successfully and `Create` the resource", func() {
 				//Act
-				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor)
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
 				//Assert
 				Expect(err).NotTo(HaveOccurred())
 			})
@@ -154,7 +197,7 @@ var _ = Describe("Adder", func() {
 			})
 			It("should call `Get` Successfully and call `Create` but return the error", func() {
 				//Act
-				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor)
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
 				//Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.CustomError))
@@ -162,64 +205,462 @@ var _ = Describe("Adder", func() {
 		})
 
 	})
-	Describe("CreateBlackBoxExporterDeployment", func() {
+	Describe("CreateBlackBoxExporterConfigMap", func() {
+		var (
+			existingRouteMonitors []runtime.Object
+		)
 		BeforeEach(func() {
-			routeMonitorAdderClient = mockClient
+			existingRouteMonitors = []runtime.Object{}
+		})
+		JustBeforeEach(func() {
+			if routeMonitorAdderClient != mockClient {
+				routeMonitorAdder.Client = fake.NewFakeClientWithScheme(scheme, existingRouteMonitors...)
+			}
+		})
+		When("func List fails unexpectedly", func() {
 			// Arrange
-			get.CalledTimes = 1
-
+			BeforeEach(func() {
+				routeMonitorAdderClient = mockClient
+				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(consterror.CustomError).Times(1)
+			})
+			It("should bubble up the error and not call `Create`", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterConfigMapExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
 		})
-
-		When("the resource(deployment) Exists", func() {
-			It("should call `Get` and not call `Create`", func() {
+		When("the resource(configmap) is Not Found", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorAdderClient = nil
+				existingRouteMonitors = []runtime.Object{
+					&v1alpha1.RouteMonitor{
+						ObjectMeta: metav1.ObjectMeta{Name: "tcp", Namespace: "tcp"},
+						Spec:       v1alpha1.RouteMonitorSpec{Module: "tcp_connect"},
+					},
+					&v1alpha1.RouteMonitor{
+						ObjectMeta: metav1.ObjectMeta{Name: "unknown", Namespace: "unknown"},
+						Spec:       v1alpha1.RouteMonitorSpec{Module: "not_a_module"},
+					},
+					&v1alpha1.RouteMonitor{
+						ObjectMeta: metav1.ObjectMeta{Name: "custom", Namespace: "custom"},
+						Spec: v1alpha1.RouteMonitorSpec{HTTP: &v1alpha1.RouteMonitorHTTPSpec{
+							FailIfBodyMatchesRegexp: []string{"error"},
+						}},
+					},
+				}
+			})
+			It("should create it with the default module and every valid module in use", func() {
 				//Act
-				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx)
+				err := routeMonitorAdder.EnsureBlackBoxExporterConfigMapExists(ctx, operatorConfig)
 				//Assert
 				Expect(err).NotTo(HaveOccurred())
-
+				configMap := corev1.ConfigMap{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &configMap)).To(Succeed())
+				expectedConfig, _ := blackboxconfig.New(blackbox.DefaultModule, "tcp_connect")
+				expectedConfig.AddModule("http_2xx_custom_custom", blackboxconfig.Module{
+					Prober: "http",
+					HTTP:   &blackboxconfig.HTTPProbe{FailIfBodyMatchesRegexp: []string{"error"}},
+				})
+				expectedRenderedConfig, _ := expectedConfig.Render()
+				Expect(configMap.Data).To(Equal(map[string]string{blackbox.BlackBoxConfigKey: expectedRenderedConfig}))
 			})
 		})
-		When("the resource(deployment) is Not Found", func() {
+		When("the operator config sets the default module", func() {
 			// Arrange
 			BeforeEach(func() {
-				get.ErrorResponse = consterror.NotFoundErr
-				create.CalledTimes = 1
+				routeMonitorAdderClient = nil
+				operatorConfig.ProbeDefaults.Module = "tcp_connect"
+				existingRouteMonitors = []runtime.Object{
+					&v1alpha1.RouteMonitor{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+				}
 			})
-			It("should call `Get` successfully and `Create` the resource(deployment)", func() {
+			It("should add it for the RouteMonitors without a module", func() {
 				//Act
-				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx)
+				err := routeMonitorAdder.EnsureBlackBoxExporterConfigMapExists(ctx, operatorConfig)
 				//Assert
 				Expect(err).NotTo(HaveOccurred())
+				configMap := corev1.ConfigMap{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &configMap)).To(Succeed())
+				expectedConfig, _ := blackboxconfig.New(blackbox.DefaultModule, "tcp_connect")
+				expectedRenderedConfig, _ := expectedConfig.Render()
+				Expect(configMap.Data).To(Equal(map[string]string{blackbox.BlackBoxConfigKey: expectedRenderedConfig}))
 			})
 		})
-		When("the resource(deployment) Get fails unexpectedly", func() {
+		When("the resource(configmap) holds an outdated config", func() {
 			// Arrange
 			BeforeEach(func() {
-				get.ErrorResponse = consterror.CustomError
+				routeMonitorAdderClient = nil
+				existingRouteMonitors = []runtime.Object{
+					&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{Name: blackbox.BlackBoxName, Namespace: blackbox.BlackBoxNamespace},
+						Data:       map[string]string{blackbox.BlackBoxConfigKey: "outdated"},
+					},
+				}
+			})
+			It("should update it", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterConfigMapExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				configMap := corev1.ConfigMap{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &configMap)).To(Succeed())
+				Expect(configMap.Data[blackbox.BlackBoxConfigKey]).NotTo(Equal("outdated"))
+			})
+		})
+	})
+	Describe("CreateBlackBoxExporterDeployment", func() {
+		var (
+			configHash         string
+			existingDeployment *appsv1.Deployment
+		)
+		BeforeEach(func() {
+			routeMonitorAdderClient = nil
+			// Without RouteMonitors the config only holds the default module
+			config, _ := blackboxconfig.New(blackbox.DefaultModule)
+			renderedConfig, _ := config.Render()
+			configHash = blackboxconfig.Hash(renderedConfig)
+			existingDeployment = nil
+		})
+		JustBeforeEach(func() {
+			if routeMonitorAdderClient != mockClient {
+				objects := []runtime.Object{}
+				if existingDeployment != nil {
+					objects = append(objects, existingDeployment)
+				}
+				routeMonitorAdder.Client = fake.NewFakeClientWithScheme(scheme, objects...)
+			}
+		})
+
+		When("listing the RouteMonitors for the config fails unexpectedly", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorAdderClient = mockClient
+				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(consterror.CustomError)
 			})
 			It("should return the error and not call `Create`", func() {
 				//Act
-				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx)
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)
 				//Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.CustomError))
 			})
 		})
+		When("the resource(deployment) is Not Found", func() {
+			// The ConfigMap created just before may not be in the cache yet, so none is present
+			It("should `Create` the resource(deployment) with the hash of the config", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				deployment := appsv1.Deployment{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &deployment)).To(Succeed())
+				Expect(deployment.Spec.Template.Annotations).To(HaveKeyWithValue(
+					blackbox.BlackBoxConfigHashAnnotation,
+					configHash))
+			})
+		})
+		When("the resource(deployment) Exists with an outdated config", func() {
+			// Arrange
+			BeforeEach(func() {
+				existingDeployment = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: blackbox.BlackBoxName, Namespace: blackbox.BlackBoxNamespace},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{blackbox.BlackBoxConfigHashAnnotation: "outdated"},
+							},
+						},
+					},
+				}
+			})
+			It("should roll the resource(deployment) by updating the hash", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				deployment := appsv1.Deployment{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &deployment)).To(Succeed())
+				Expect(deployment.Spec.Template.Annotations).To(HaveKeyWithValue(
+					blackbox.BlackBoxConfigHashAnnotation,
+					configHash))
+			})
+		})
+		When("the resource(deployment) Exists with the current config", func() {
+			It("should leave the resource(deployment) untouched", func() {
+				// Arrange
+				Expect(routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)).To(Succeed())
+				before := appsv1.Deployment{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &before)).To(Succeed())
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				after := appsv1.Deployment{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &after)).To(Succeed())
+				Expect(after.ResourceVersion).To(Equal(before.ResourceVersion))
+			})
+		})
+		When("the resource(deployment) was scaled down and its image edited", func() {
+			var expected appsv1.Deployment
+			// Arrange
+			JustBeforeEach(func() {
+				Expect(routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)).To(Succeed())
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &expected)).To(Succeed())
+
+				drifted := expected.DeepCopy()
+				var replicas int32 = 0
+				drifted.Spec.Replicas = &replicas
+				drifted.Spec.Template.Spec.Containers[0].Image = "example.com/blackbox-exporter:edited"
+				drifted.Spec.Template.Spec.Containers[0].Ports = nil
+				drifted.Spec.Template.Annotations["example.com/restartedAt"] = "now"
+				drifted.Spec.Template.Spec.NodeSelector = map[string]string{"node-role.kubernetes.io/infra": ""}
+				Expect(routeMonitorAdder.Update(ctx, drifted)).To(Succeed())
+			})
+			It("should put back the owned fields and keep the others", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				deployment := appsv1.Deployment{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &deployment)).To(Succeed())
+				Expect(deployment.Spec.Replicas).To(Equal(expected.Spec.Replicas))
+				Expect(deployment.Spec.Template.Spec.Containers).To(Equal(expected.Spec.Template.Spec.Containers))
+				Expect(deployment.Spec.Template.Annotations).To(HaveKeyWithValue("example.com/restartedAt", "now"))
+				Expect(deployment.Spec.Template.Spec.NodeSelector).To(HaveKey("node-role.kubernetes.io/infra"))
+			})
+		})
+		When("the container of the resource(deployment) was removed", func() {
+			// Arrange
+			BeforeEach(func() {
+				existingDeployment = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: blackbox.BlackBoxName, Namespace: blackbox.BlackBoxNamespace},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{Name: "sidecar", Image: "example.com/sidecar"}},
+							},
+						},
+					},
+				}
+			})
+			It("should add the container and volume back next to the other containers", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				deployment := appsv1.Deployment{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &deployment)).To(Succeed())
+				Expect(deployment.Spec.Template.Spec.Containers).To(HaveLen(2))
+				Expect(deployment.Spec.Template.Spec.Containers[0].Name).To(Equal("sidecar"))
+				Expect(deployment.Spec.Template.Spec.Containers[1].Name).To(Equal("blackbox-exporter"))
+				Expect(deployment.Spec.Template.Spec.Volumes).To(HaveLen(1))
+				Expect(*deployment.Spec.Replicas).To(Equal(blackbox.DefaultBlackBoxReplicas))
+			})
+		})
+		When("the RouteMonitorOperatorConfig configures the exporter", func() {
+			var replicas int32 = 3
+			// Arrange
+			BeforeEach(func() {
+				operatorConfig.BlackBoxExporter = v1alpha1.BlackBoxExporterConfig{
+					Image:    "registry.example.com/blackbox-exporter:v0.18.0",
+					Replicas: &replicas,
+					Resources: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+					},
+					NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+					Tolerations:  []corev1.Toleration{{Key: "node-role.kubernetes.io/infra", Effect: corev1.TaintEffectNoSchedule}},
+				}
+			})
+			JustBeforeEach(func() {
+				Expect(routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, v1alpha1.RouteMonitorOperatorConfigSpec{})).To(Succeed())
+			})
+			It("should roll the configured settings out to the resource(deployment)", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				deployment := appsv1.Deployment{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &deployment)).To(Succeed())
+				Expect(*deployment.Spec.Replicas).To(Equal(replicas))
+				podSpec := deployment.Spec.Template.Spec
+				Expect(podSpec.Containers[0].Image).To(Equal("registry.example.com/blackbox-exporter:v0.18.0"))
+				Expect(podSpec.Containers[0].Resources).To(Equal(*operatorConfig.BlackBoxExporter.Resources))
+				Expect(podSpec.NodeSelector).To(Equal(operatorConfig.BlackBoxExporter.NodeSelector))
+				Expect(podSpec.Tolerations).To(Equal(operatorConfig.BlackBoxExporter.Tolerations))
+			})
+		})
+		When("the resource(deployment) is created", func() {
+			It("should spread the exporters and check their health", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				deployment := appsv1.Deployment{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &deployment)).To(Succeed())
+				podSpec := deployment.Spec.Template.Spec
+				Expect(podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+				Expect(podSpec.TopologySpreadConstraints).To(HaveLen(1))
+				container := podSpec.Containers[0]
+				Expect(container.LivenessProbe.HTTPGet.Path).To(Equal(blackbox.BlackBoxHealthPath))
+				Expect(container.ReadinessProbe.HTTPGet.Path).To(Equal(blackbox.BlackBoxHealthPath))
+				Expect(container.Resources.Requests).To(HaveKey(corev1.ResourceMemory))
+			})
+			It("should run the exporter without privileges", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				deployment := appsv1.Deployment{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &deployment)).To(Succeed())
+				Expect(*deployment.Spec.Template.Spec.SecurityContext.RunAsNonRoot).To(BeTrue())
+				securityContext := deployment.Spec.Template.Spec.Containers[0].SecurityContext
+				Expect(*securityContext.RunAsNonRoot).To(BeTrue())
+				Expect(*securityContext.ReadOnlyRootFilesystem).To(BeTrue())
+				Expect(*securityContext.AllowPrivilegeEscalation).To(BeFalse())
+				Expect(securityContext.Capabilities.Drop).To(ConsistOf(corev1.Capability("ALL")))
+			})
+		})
+		When("the image of the exporter was configured", func() {
+			// Arrange
+			BeforeEach(func() {
+				Expect(blackbox.ConfigureImage("mirror.example.com/blackbox-exporter@sha256:0123")).To(Succeed())
+				operatorConfig.BlackBoxExporter.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "mirror-pull-secret"}}
+			})
+			AfterEach(func() {
+				Expect(blackbox.ConfigureImage(blackbox.DefaultBlackBoxImage)).To(Succeed())
+			})
+			JustBeforeEach(func() {
+				Expect(routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, v1alpha1.RouteMonitorOperatorConfigSpec{})).To(Succeed())
+			})
+			It("should roll the resource(deployment) to the image and pull it with the secrets", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				deployment := appsv1.Deployment{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &deployment)).To(Succeed())
+				Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("mirror.example.com/blackbox-exporter@sha256:0123"))
+				Expect(deployment.Spec.Template.Spec.ImagePullSecrets).To(Equal(operatorConfig.BlackBoxExporter.ImagePullSecrets))
+			})
+		})
+		When("kube-rbac-proxy was enabled", func() {
+			// Arrange
+			BeforeEach(func() {
+				operatorConfig.BlackBoxExporter.KubeRBACProxy = v1alpha1.KubeRBACProxyConfig{Enabled: true}
+			})
+			It("should serve the exporter port through kube-rbac-proxy with the serving certificate", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				deployment := appsv1.Deployment{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &deployment)).To(Succeed())
+				podSpec := deployment.Spec.Template.Spec
+				Expect(podSpec.ServiceAccountName).To(Equal(blackbox.BlackBoxName))
+				Expect(podSpec.Containers).To(HaveLen(2))
+				exporter, proxy := podSpec.Containers[0], podSpec.Containers[1]
+				Expect(exporter.Args).To(ContainElement("--web.listen-address=127.0.0.1:9116"))
+				Expect(exporter.Ports).To(BeEmpty())
+				for _, probe := range []*corev1.Probe{exporter.LivenessProbe, exporter.ReadinessProbe} {
+					Expect(probe.HTTPGet.Port).To(Equal(intstr.FromInt(blackbox.BlackBoxPortNumber)))
+					Expect(probe.HTTPGet.Scheme).To(Equal(corev1.URISchemeHTTPS))
+				}
+				Expect(proxy.Name).To(Equal(blackbox.KubeRBACProxyName))
+				Expect(proxy.Image).To(Equal(blackbox.DefaultKubeRBACProxyImage))
+				Expect(proxy.Args).To(ContainElement("--upstream=http://127.0.0.1:9116/"))
+				Expect(proxy.Ports[0].Name).To(Equal(blackbox.BlackBoxPortName))
+				Expect(podSpec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "tls",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: blackbox.BlackBoxTLSSecretName},
+					},
+				}))
+			})
+			It("should remove kube-rbac-proxy again once it is disabled", func() {
+				// Arrange
+				Expect(routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)).To(Succeed())
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, v1alpha1.RouteMonitorOperatorConfigSpec{})
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				deployment := appsv1.Deployment{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &deployment)).To(Succeed())
+				podSpec := deployment.Spec.Template.Spec
+				Expect(podSpec.Containers).To(HaveLen(1))
+				Expect(podSpec.Containers[0].Args).NotTo(ContainElement("--web.listen-address=127.0.0.1:9116"))
+				Expect(podSpec.Containers[0].Ports[0].Name).To(Equal(blackbox.BlackBoxPortName))
+				Expect(podSpec.Volumes).To(HaveLen(1))
+			})
+		})
 		When("the resource(deployment) Create fails unexpectedly", func() {
 			// Arrange
 			BeforeEach(func() {
-				get.ErrorResponse = consterror.NotFoundErr
+				routeMonitorAdderClient = mockClient
 				create = helper.CustomErrorHappensOnce()
 			})
+			JustBeforeEach(func() {
+				gomock.InOrder(
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(consterror.NotFoundErr),
+				)
+			})
 			It("should call `Get` Successfully and call `Create` but return the error", func() {
 				//Act
-				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx)
+				err := routeMonitorAdder.EnsureBlackBoxExporterDeploymentExists(ctx, operatorConfig)
 				//Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.CustomError))
 			})
 		})
 	})
+	Describe("CreateBlackBoxExporterServiceAccount", func() {
+		BeforeEach(func() {
+			routeMonitorAdderClient = fake.NewFakeClientWithScheme(scheme)
+		})
+
+		When("the resource(serviceaccount) is Not Found", func() {
+			It("should create the resource(serviceaccount) of the exporter", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterServiceAccountExists(ctx)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				serviceAccount := corev1.ServiceAccount{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &serviceAccount)).To(Succeed())
+				Expect(serviceAccount.Labels).To(Equal(blackbox.GenerateBlackBoxLables()))
+			})
+		})
+		When("the resource(serviceaccount) Exists", func() {
+			It("should leave the resource(serviceaccount) untouched", func() {
+				// Arrange
+				Expect(routeMonitorAdder.EnsureBlackBoxExporterServiceAccountExists(ctx)).To(Succeed())
+				before := corev1.ServiceAccount{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &before)).To(Succeed())
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterServiceAccountExists(ctx)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				after := corev1.ServiceAccount{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &after)).To(Succeed())
+				Expect(after.ResourceVersion).To(Equal(before.ResourceVersion))
+			})
+		})
+		When("the resource(serviceaccount) Get fails unexpectedly", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorAdderClient = mockClient
+				get = helper.CustomErrorHappensOnce()
+			})
+			It("should return the error and not call `Create`", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterServiceAccountExists(ctx)
+				//Assert
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+	})
 	Describe("CreateBlackBoxExporterService", func() {
 		BeforeEach(func() {
 			routeMonitorAdderClient = mockClient
@@ -228,14 +669,51 @@ var _ = Describe("Adder", func() {
 		When("the resource(service) Exists", func() {
 			// Arrange
 			BeforeEach(func() {
-				get.CalledTimes = 1
+				routeMonitorAdderClient = fake.NewFakeClientWithScheme(scheme)
 			})
-			It("should call `Get` and not call `Create`", func() {
+			It("should leave the resource(service) untouched", func() {
+				// Arrange
+				Expect(routeMonitorAdder.EnsureBlackBoxExporterServiceExists(ctx)).To(Succeed())
+				before := corev1.Service{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &before)).To(Succeed())
 				//Act
 				err := routeMonitorAdder.EnsureBlackBoxExporterServiceExists(ctx)
 				//Assert
 				Expect(err).NotTo(HaveOccurred())
-
+				after := corev1.Service{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &after)).To(Succeed())
+				Expect(after.ResourceVersion).To(Equal(before.ResourceVersion))
+			})
+		})
+		When("the selector and ports of the resource(service) were edited", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorAdderClient = fake.NewFakeClientWithScheme(scheme, &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      blackbox.BlackBoxName,
+						Namespace: blackbox.BlackBoxNamespace,
+						Labels:    map[string]string{"team": "monitoring"},
+					},
+					Spec: corev1.ServiceSpec{
+						ClusterIP: "172.30.0.10",
+						Selector:  map[string]string{"app": "other"},
+						Ports:     []corev1.ServicePort{{Name: "http", Port: 80}},
+					},
+				})
+			})
+			It("should put back the selector and ports and keep the other fields", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterServiceExists(ctx)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				service := corev1.Service{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &service)).To(Succeed())
+				Expect(service.Spec.Selector).To(Equal(blackbox.GenerateBlackBoxLables()))
+				Expect(service.Spec.Ports).To(HaveLen(1))
+				Expect(service.Spec.Ports[0].Name).To(Equal(blackbox.BlackBoxPortName))
+				Expect(service.Spec.Ports[0].Port).To(BeEquivalentTo(blackbox.BlackBoxPortNumber))
+				Expect(service.Spec.ClusterIP).To(Equal("172.30.0.10"))
+				Expect(service.Labels).To(HaveKeyWithValue("team", "monitoring"))
 			})
 		})
 		When("the resource(service) is Not Found", func() {
@@ -279,6 +757,165 @@ var _ = Describe("Adder", func() {
 			})
 		})
 	})
+	Describe("CreateBlackBoxExporterPodDisruptionBudget", func() {
+		BeforeEach(func() {
+			routeMonitorAdderClient = fake.NewFakeClientWithScheme(scheme)
+		})
+
+		When("the resource(pdb) is Not Found", func() {
+			It("should create the resource(pdb) allowing one exporter to be evicted", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterPodDisruptionBudgetExists(ctx)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				pdb := policyv1beta1.PodDisruptionBudget{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &pdb)).To(Succeed())
+				Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+				Expect(pdb.Spec.Selector.MatchLabels).To(Equal(blackbox.GenerateBlackBoxLables()))
+			})
+		})
+		When("the resource(pdb) Exists", func() {
+			It("should leave the resource(pdb) untouched", func() {
+				// Arrange
+				Expect(routeMonitorAdder.EnsureBlackBoxExporterPodDisruptionBudgetExists(ctx)).To(Succeed())
+				before := policyv1beta1.PodDisruptionBudget{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &before)).To(Succeed())
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterPodDisruptionBudgetExists(ctx)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				after := policyv1beta1.PodDisruptionBudget{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &after)).To(Succeed())
+				Expect(after.ResourceVersion).To(Equal(before.ResourceVersion))
+			})
+		})
+		When("the resource(pdb) was edited to block every eviction", func() {
+			// Arrange
+			BeforeEach(func() {
+				maxUnavailable := intstr.FromInt(0)
+				routeMonitorAdderClient = fake.NewFakeClientWithScheme(scheme, &policyv1beta1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{Name: blackbox.BlackBoxName, Namespace: blackbox.BlackBoxNamespace},
+					Spec:       policyv1beta1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable},
+				})
+			})
+			It("should put back the spec of the resource(pdb)", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterPodDisruptionBudgetExists(ctx)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				pdb := policyv1beta1.PodDisruptionBudget{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &pdb)).To(Succeed())
+				Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+				Expect(pdb.Labels).To(Equal(blackbox.GenerateBlackBoxLables()))
+			})
+		})
+		When("the resource(pdb) Get fails unexpectedly", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorAdderClient = mockClient
+				get = helper.CustomErrorHappensOnce()
+			})
+			It("should return the error and not call `Create`", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterPodDisruptionBudgetExists(ctx)
+				//Assert
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+	})
+	Describe("CreateBlackBoxExporterNetworkPolicy", func() {
+		BeforeEach(func() {
+			routeMonitorAdderClient = fake.NewFakeClientWithScheme(scheme)
+		})
+
+		When("the resource(networkpolicy) is Not Found", func() {
+			It("should only allow Prometheus to reach the exporter port", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterNetworkPolicyExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				networkPolicy := networkingv1.NetworkPolicy{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &networkPolicy)).To(Succeed())
+				Expect(networkPolicy.Spec.PodSelector.MatchLabels).To(Equal(blackbox.GenerateBlackBoxLables()))
+				Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+				Expect(networkPolicy.Spec.Ingress).To(HaveLen(1))
+				rule := networkPolicy.Spec.Ingress[0]
+				Expect(rule.Ports).To(HaveLen(1))
+				Expect(rule.Ports[0].Port.String()).To(Equal(blackbox.BlackBoxPortName))
+				Expect(rule.From).To(HaveLen(1))
+				Expect(rule.From[0].PodSelector.MatchExpressions[0].Key).To(Equal(blackbox.PrometheusPodLabel))
+				Expect(rule.From[0].NamespaceSelector).To(Equal(&metav1.LabelSelector{
+					MatchLabels: map[string]string{blackbox.ClusterMonitoringNamespaceLabel: "true"},
+				}))
+			})
+		})
+		When("the operator config selects the namespaces of Prometheus", func() {
+			// Arrange
+			BeforeEach(func() {
+				operatorConfig.BlackBoxExporter.PrometheusNamespaceSelector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{"monitoring": "platform"},
+				}
+			})
+			It("should only allow Prometheus of those namespaces to reach the exporter port", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterNetworkPolicyExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				networkPolicy := networkingv1.NetworkPolicy{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &networkPolicy)).To(Succeed())
+				Expect(networkPolicy.Spec.Ingress[0].From[0].NamespaceSelector).To(Equal(operatorConfig.BlackBoxExporter.PrometheusNamespaceSelector))
+			})
+		})
+		When("the resource(networkpolicy) Exists", func() {
+			It("should leave the resource(networkpolicy) untouched", func() {
+				// Arrange
+				Expect(routeMonitorAdder.EnsureBlackBoxExporterNetworkPolicyExists(ctx, operatorConfig)).To(Succeed())
+				before := networkingv1.NetworkPolicy{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &before)).To(Succeed())
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterNetworkPolicyExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				after := networkingv1.NetworkPolicy{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &after)).To(Succeed())
+				Expect(after.ResourceVersion).To(Equal(before.ResourceVersion))
+			})
+		})
+		When("the resource(networkpolicy) was opened to every pod", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorAdderClient = fake.NewFakeClientWithScheme(scheme, &networkingv1.NetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: blackbox.BlackBoxName, Namespace: blackbox.BlackBoxNamespace},
+					Spec: networkingv1.NetworkPolicySpec{
+						PodSelector: metav1.LabelSelector{MatchLabels: blackbox.GenerateBlackBoxLables()},
+						Ingress:     []networkingv1.NetworkPolicyIngressRule{{}},
+					},
+				})
+			})
+			It("should put back the spec of the resource(networkpolicy)", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterNetworkPolicyExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).NotTo(HaveOccurred())
+				networkPolicy := networkingv1.NetworkPolicy{}
+				Expect(routeMonitorAdder.Get(ctx, blackbox.BlackBoxNamespacedName, &networkPolicy)).To(Succeed())
+				Expect(networkPolicy.Spec.Ingress[0].From).To(HaveLen(1))
+			})
+		})
+		When("the resource(networkpolicy) Get fails unexpectedly", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorAdderClient = mockClient
+				get = helper.CustomErrorHappensOnce()
+			})
+			It("should return the error and not call `Create`", func() {
+				//Act
+				err := routeMonitorAdder.EnsureBlackBoxExporterNetworkPolicyExists(ctx, operatorConfig)
+				//Assert
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+	})
 	Describe("CreateServiceMonitorResource", func() {
 		When("the RouteMonitor has no Host", func() {
 			// Arrange
@@ -288,25 +925,204 @@ var _ = Describe("Adder", func() {
 			})
 			It("should return No Host error", func() {
 				// Act
-				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor)
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
 				// Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(customerrors.NoHost))
 			})
 		})
-		When("func 'Update' failed unexpectidly", func() {
+		When("the RouteMonitor has a scrapeTimeout bigger than the interval", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorAdderClient = mockClient
+				routeMonitorSpec = v1alpha1.RouteMonitorSpec{
+					Interval:      "10s",
+					ScrapeTimeout: "15s",
+				}
+			})
+			It("should return an Invalid CR error", func() {
+				// Act
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+		When("the RouteMonitor only sets an interval that is not bigger than the default scrapeTimeout", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorAdderClient = mockClient
+				routeMonitorSpec = v1alpha1.RouteMonitorSpec{
+					Interval: "10s",
+				}
+			})
+			It("should return an Invalid CR error", func() {
+				// Act
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+		When("the RouteMonitor uses an unknown module", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorAdderClient = mockClient
+				routeMonitorSpec = v1alpha1.RouteMonitorSpec{
+					Module: "not_a_module",
+				}
+			})
+			It("should return an Invalid CR error", func() {
+				// Act
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+		When("the ServiceMonitor is created", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorAdderClient = fake.NewFakeClientWithScheme(scheme)
+				routeMonitorStatus = v1alpha1.RouteMonitorStatus{
+					RouteURLs: []v1alpha1.RouteMonitorURL{
+						{
+							RouterName: "default",
+							URL:        "https://fake-route-url/api",
+							Scheme:     "https",
+							Host:       "fake-route-url",
+							Path:       "/api",
+						},
+					},
+				}
+			})
+			JustBeforeEach(func() {
+				routeMonitor.Name = "fake-name"
+				routeMonitor.Namespace = "fake-namespace"
+			})
+			It("should probe the full url with http modules", func() {
+				// Act
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				serviceMonitor := monitoringv1.ServiceMonitor{}
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &serviceMonitor)).To(Succeed())
+				Expect(serviceMonitor.Spec.Endpoints[0].Params).To(Equal(map[string][]string{
+					"module": {"http_2xx"},
+					"target": {"https://fake-route-url/api"},
+				}))
+			})
+			It("should probe host and port with tcp modules", func() {
+				// Arrange
+				routeMonitor.Spec.Module = "tcp_connect"
+				// Act
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				serviceMonitor := monitoringv1.ServiceMonitor{}
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &serviceMonitor)).To(Succeed())
+				Expect(serviceMonitor.Spec.Endpoints[0].Params).To(Equal(map[string][]string{
+					"module": {"tcp_connect"},
+					"target": {"fake-route-url:443"},
+				}))
+			})
+			It("should create a Probe instead when the RouteMonitor uses the Probe backend", func() {
+				// Arrange
+				routeMonitor.Spec.Backend = v1alpha1.BackendProbe
+				// Act
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &monitoringv1.ServiceMonitor{})).
+					To(MatchError(ContainSubstring("not found")))
+				probe := monitoringv1.Probe{}
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &probe)).To(Succeed())
+				Expect(probe.Spec.ProberSpec.URL).To(Equal("blackbox-exporter.openshift-monitoring.svc:9115"))
+				Expect(probe.Spec.Module).To(Equal("http_2xx"))
+				Expect(probe.Spec.Targets.StaticConfig.Targets).To(Equal([]string{"https://fake-route-url/api"}))
+				Expect(probe.Spec.Interval).To(Equal(routeMonitor.GetInterval()))
+				Expect(routeMonitor.OwnsGeneratedResource(&probe)).To(BeTrue())
+			})
+			It("should reject the Probe backend when kube-rbac-proxy is enabled", func() {
+				// Arrange
+				routeMonitor.Spec.Backend = v1alpha1.BackendProbe
+				operatorConfig.BlackBoxExporter.KubeRBACProxy = v1alpha1.KubeRBACProxyConfig{Enabled: true}
+				// Act
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(errors.Is(err, customerrors.InvalidCR)).To(BeTrue())
+			})
+			It("should scrape through kube-rbac-proxy when it is enabled", func() {
+				// Arrange
+				operatorConfig.BlackBoxExporter.KubeRBACProxy = v1alpha1.KubeRBACProxyConfig{Enabled: true}
+				// Act
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				serviceMonitor := monitoringv1.ServiceMonitor{}
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &serviceMonitor)).To(Succeed())
+				endpoint := serviceMonitor.Spec.Endpoints[0]
+				Expect(endpoint.Scheme).To(Equal("https"))
+				Expect(endpoint.BearerTokenFile).To(Equal(blackbox.ServiceAccountTokenFile))
+				Expect(endpoint.TLSConfig.CAFile).To(Equal(blackbox.DefaultServingCAFile))
+				Expect(endpoint.TLSConfig.ServerName).To(Equal("blackbox-exporter.openshift-monitoring.svc"))
+			})
+			When("the ServiceMonitors are placed next to the RouteMonitors", func() {
+				BeforeEach(func() {
+					Expect(blackbox.Configure(blackbox.DefaultBlackBoxNamespace, blackbox.ServiceMonitorInRouteMonitorNamespace)).To(Succeed())
+				})
+				AfterEach(func() {
+					Expect(blackbox.Configure(blackbox.DefaultBlackBoxNamespace, blackbox.ServiceMonitorInExporterNamespace)).To(Succeed())
+				})
+				It("should create the ServiceMonitor in the namespace of the RouteMonitor, selecting the exporter namespace", func() {
+					// Act
+					_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+					// Assert
+					Expect(err).NotTo(HaveOccurred())
+					serviceMonitor := monitoringv1.ServiceMonitor{}
+					Expect(routeMonitorAdder.Get(ctx, types.NamespacedName{
+						Name:      routeMonitor.TemplateForServiceMonitorName().Name,
+						Namespace: "fake-namespace",
+					}, &serviceMonitor)).To(Succeed())
+					Expect(serviceMonitor.Spec.NamespaceSelector.MatchNames).To(Equal([]string{blackbox.DefaultBlackBoxNamespace}))
+				})
+			})
+			It("should add an endpoint per ingress", func() {
+				// Arrange
+				routeMonitor.Status.RouteURLs = append(routeMonitor.Status.RouteURLs, v1alpha1.RouteMonitorURL{
+					RouterName: "sharded",
+					URL:        "https://fake-sharded-url/api",
+					Scheme:     "https",
+					Host:       "fake-sharded-url",
+					Path:       "/api",
+				})
+				// Act
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				serviceMonitor := monitoringv1.ServiceMonitor{}
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &serviceMonitor)).To(Succeed())
+				Expect(serviceMonitor.Spec.Endpoints).To(HaveLen(2))
+				Expect(serviceMonitor.Spec.Endpoints[1].Params["target"]).To(Equal([]string{"https://fake-sharded-url/api"}))
+				Expect(serviceMonitor.Spec.Endpoints[1].MetricRelabelConfigs).To(ContainElement(&monitoringv1.RelabelConfig{
+					Replacement: "sharded",
+					TargetLabel: "RouteMonitorRouter",
+				}))
+			})
+		})
+		When("func 'Patch' failed unexpectidly", func() {
 			// Arrange
 			BeforeEach(func() {
 				routeMonitorAdderClient = mockClient
-				update = helper.CustomErrorHappensOnce()
+				patch = helper.CustomErrorHappensOnce()
 				routeMonitorFinalizers = nil
 				routeMonitorStatus = v1alpha1.RouteMonitorStatus{
-					RouteURL: "fake-route-url",
+					RouteURLs: []v1alpha1.RouteMonitorURL{{URL: "fake-route-url"}},
 				}
 			})
 			It("should bubble up the error", func() {
 				// Act
-				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor)
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
 				// Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.CustomError))
@@ -316,21 +1132,246 @@ var _ = Describe("Adder", func() {
 			// Arrange
 			BeforeEach(func() {
 				routeMonitorAdderClient = mockClient
-				update.CalledTimes = 1
+				patch.CalledTimes = 1
 				routeMonitorFinalizers = nil
 				routeMonitorStatus = v1alpha1.RouteMonitorStatus{
-					RouteURL: "fake-route-url",
+					RouteURLs: []v1alpha1.RouteMonitorURL{{URL: "fake-route-url"}},
 				}
 			})
 			It("Should update the RouteMonitor with the finalizer", func() {
 				// Act
-				resp, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor)
+				resp, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
 				// Assert
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp).NotTo(BeNil())
 				Expect(resp).To(Equal(utilreconcile.StopOperation()))
 			})
 		})
+		When("the RouteMonitor without a Finalizer has the probe defaults applied", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorFinalizers = nil
+				routeMonitorAdderClient = fake.NewFakeClientWithScheme(scheme, &v1alpha1.RouteMonitor{
+					ObjectMeta: metav1.ObjectMeta{Name: "fake-name", Namespace: "fake-namespace"},
+				})
+			})
+			JustBeforeEach(func() {
+				routeMonitor.Name = "fake-name"
+				routeMonitor.Namespace = "fake-namespace"
+				routeMonitor.ApplyProbeDefaults(v1alpha1.ProbeDefaults{Interval: "1m", Module: "tcp_connect"})
+			})
+			It("should store the finalizer but not the defaults", func() {
+				// Act
+				_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				stored := v1alpha1.RouteMonitor{}
+				Expect(routeMonitorAdder.Get(ctx, types.NamespacedName{Name: "fake-name", Namespace: "fake-namespace"}, &stored)).To(Succeed())
+				Expect(stored.Finalizers).To(Equal(routemonitorconst.FinalizerList))
+				Expect(stored.Spec).To(Equal(v1alpha1.RouteMonitorSpec{}))
+			})
+		})
+	})
+
+	Describe("EnsureServiceMonitorResourceUpToDate", func() {
+		BeforeEach(func() {
+			// Arrange
+			routeMonitorAdderClient = fake.NewFakeClientWithScheme(scheme)
+			routeMonitorStatus = v1alpha1.RouteMonitorStatus{
+				RouteURLs: []v1alpha1.RouteMonitorURL{{URL: "https://fake-route-url", Scheme: "https", Host: "fake-route-url"}},
+			}
+		})
+		JustBeforeEach(func() {
+			// Arrange
+			routeMonitor.Name = "fake-name"
+			routeMonitor.Namespace = "fake-namespace"
+			routeMonitor.UID = "fake-uid"
+			_, err := routeMonitorAdder.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		When("the ServiceMonitor matches the template", func() {
+			It("should not patch it", func() {
+				// Act
+				patched, err := routeMonitorAdder.EnsureServiceMonitorResourceUpToDate(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(patched).To(BeFalse())
+			})
+		})
+		When("the host of the Route changed", func() {
+			It("should patch the target and the url label", func() {
+				// Arrange
+				routeMonitor.Status.RouteURLs = []v1alpha1.RouteMonitorURL{{URL: "https://new-route-url", Scheme: "https", Host: "new-route-url"}}
+				// Act
+				patched, err := routeMonitorAdder.EnsureServiceMonitorResourceUpToDate(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(patched).To(BeTrue())
+				serviceMonitor := monitoringv1.ServiceMonitor{}
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &serviceMonitor)).To(Succeed())
+				Expect(serviceMonitor.Spec.Endpoints).To(HaveLen(1))
+				Expect(serviceMonitor.Spec.Endpoints[0].Params["target"]).To(Equal([]string{"https://new-route-url"}))
+				Expect(serviceMonitor.Spec.Endpoints[0].MetricRelabelConfigs).To(ContainElement(&monitoringv1.RelabelConfig{
+					Replacement: "https://new-route-url",
+					TargetLabel: "RouteMonitorUrl",
+				}))
+			})
+		})
+		When("the interval changed", func() {
+			It("should patch the interval of the endpoints", func() {
+				// Arrange
+				routeMonitor.Spec.Interval = "1m"
+				// Act
+				patched, err := routeMonitorAdder.EnsureServiceMonitorResourceUpToDate(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(patched).To(BeTrue())
+				serviceMonitor := monitoringv1.ServiceMonitor{}
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &serviceMonitor)).To(Succeed())
+				Expect(serviceMonitor.Spec.Endpoints[0].Interval).To(Equal("1m"))
+			})
+		})
+		When("the RouteMonitor uses the Probe backend and its Route changed", func() {
+			BeforeEach(func() {
+				// Arrange
+				routeMonitorSpec.Backend = v1alpha1.BackendProbe
+			})
+			It("should patch the targets of the Probe", func() {
+				// Arrange
+				routeMonitor.Status.RouteURLs = []v1alpha1.RouteMonitorURL{{URL: "https://new-route-url", Scheme: "https", Host: "new-route-url"}}
+				// Act
+				patched, err := routeMonitorAdder.EnsureServiceMonitorResourceUpToDate(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(patched).To(BeTrue())
+				probe := monitoringv1.Probe{}
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &probe)).To(Succeed())
+				Expect(probe.Spec.Targets.StaticConfig.Targets).To(Equal([]string{"https://new-route-url"}))
+			})
+		})
+		When("labels were configured for the ServiceMonitors", func() {
+			It("should add the labels and keep the owner label", func() {
+				// Arrange
+				config := v1alpha1.RouteMonitorOperatorConfigSpec{ServiceMonitor: v1alpha1.ServiceMonitorConfig{
+					Labels: map[string]string{"team": "platform", routemonitorconst.ServiceMonitorOwnerUIDLabel: "other-uid"},
+				}}
+				// Act
+				patched, err := routeMonitorAdder.EnsureServiceMonitorResourceUpToDate(ctx, routeMonitor, config)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(patched).To(BeTrue())
+				serviceMonitor := monitoringv1.ServiceMonitor{}
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &serviceMonitor)).To(Succeed())
+				Expect(serviceMonitor.Labels).To(HaveKeyWithValue("team", "platform"))
+				Expect(serviceMonitor.Labels).To(HaveKeyWithValue(routemonitorconst.ServiceMonitorOwnerUIDLabel, "fake-uid"))
+			})
+		})
+	})
+
+	Describe("EnsurePrometheusRuleResourceExists", func() {
+		BeforeEach(func() {
+			// Arrange
+			routeMonitorAdderClient = fake.NewFakeClientWithScheme(scheme)
+			routeMonitorStatus = v1alpha1.RouteMonitorStatus{
+				RouteURLs: []v1alpha1.RouteMonitorURL{{URL: "https://fake-route.url", Scheme: "https", Host: "fake-route.url"}},
+			}
+			routeMonitorSpec.Alerting = &v1alpha1.RouteMonitorAlerting{}
+		})
+		JustBeforeEach(func() {
+			// Arrange
+			routeMonitor.Name = "fake-name"
+			routeMonitor.Namespace = "fake-namespace"
+			routeMonitor.UID = "fake-uid"
+		})
+		When("the RouteMonitor has no alerting", func() {
+			BeforeEach(func() {
+				// Arrange
+				routeMonitorSpec.Alerting = nil
+			})
+			It("should not create a PrometheusRule", func() {
+				// Act
+				err := routeMonitorAdder.EnsurePrometheusRuleResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &monitoringv1.PrometheusRule{})).
+					To(MatchError(ContainSubstring("not found")))
+			})
+		})
+		When("the RouteMonitor has alerting", func() {
+			BeforeEach(func() {
+				// Arrange
+				routeMonitorSpec.Alerting = &v1alpha1.RouteMonitorAlerting{Labels: map[string]string{"team": "platform", "severity": "none"}}
+			})
+			It("should create a PrometheusRule alerting on the probes of the RouteMonitor with the defaults", func() {
+				// Act
+				err := routeMonitorAdder.EnsurePrometheusRuleResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				prometheusRule := monitoringv1.PrometheusRule{}
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &prometheusRule)).To(Succeed())
+				Expect(prometheusRule.Labels).To(HaveKeyWithValue(routemonitorconst.ServiceMonitorOwnerUIDLabel, "fake-uid"))
+				Expect(prometheusRule.Spec.Groups).To(HaveLen(1))
+				Expect(prometheusRule.Spec.Groups[0].Rules).To(HaveLen(1))
+				rule := prometheusRule.Spec.Groups[0].Rules[0]
+				Expect(rule.Alert).To(Equal(blackbox.ProbeFailingAlertName))
+				Expect(rule.Expr.String()).To(Equal(`probe_success{RouteMonitorNamespace="` + routeMonitor.Namespace + `",RouteMonitorName="` + routeMonitor.Name + `"} == 0`))
+				Expect(rule.For).To(Equal(blackbox.DefaultAlertFor))
+				Expect(rule.Labels).To(Equal(map[string]string{"team": "platform", "severity": blackbox.DefaultAlertSeverity}))
+			})
+		})
+		When("the RouteMonitor uses the Probe backend", func() {
+			BeforeEach(func() {
+				// Arrange
+				routeMonitorSpec.Backend = v1alpha1.BackendProbe
+			})
+			It("should alert on the job of the Probe", func() {
+				// Act
+				err := routeMonitorAdder.EnsurePrometheusRuleResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				prometheusRule := monitoringv1.PrometheusRule{}
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &prometheusRule)).To(Succeed())
+				Expect(prometheusRule.Spec.Groups[0].Rules[0].Expr.String()).
+					To(Equal(`probe_success{job="` + routeMonitor.TemplateForServiceMonitorName().Name + `"} == 0`))
+			})
+		})
+		When("the alerting of the RouteMonitor changed", func() {
+			JustBeforeEach(func() {
+				// Arrange
+				Expect(routeMonitorAdder.EnsurePrometheusRuleResourceExists(ctx, routeMonitor, operatorConfig)).To(Succeed())
+				routeMonitor.Spec.Alerting = &v1alpha1.RouteMonitorAlerting{For: "15m", Severity: "critical"}
+			})
+			It("should patch the PrometheusRule", func() {
+				// Act
+				err := routeMonitorAdder.EnsurePrometheusRuleResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				prometheusRule := monitoringv1.PrometheusRule{}
+				Expect(routeMonitorAdder.Get(ctx, routeMonitor.TemplateForServiceMonitorName(), &prometheusRule)).To(Succeed())
+				rule := prometheusRule.Spec.Groups[0].Rules[0]
+				Expect(rule.For).To(Equal("15m"))
+				Expect(rule.Labels).To(HaveKeyWithValue("severity", "critical"))
+			})
+		})
+		When("the PrometheusRule belongs to another RouteMonitor", func() {
+			BeforeEach(func() {
+				// Arrange
+				other := v1alpha1.RouteMonitor{ObjectMeta: metav1.ObjectMeta{Name: "fake-name", Namespace: "fake-namespace"}}
+				routeMonitorAdderClient = fake.NewFakeClientWithScheme(scheme, &monitoringv1.PrometheusRule{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      other.TemplateForServiceMonitorName().Name,
+						Namespace: other.TemplateForServiceMonitorName().Namespace,
+						Labels:    map[string]string{routemonitorconst.ServiceMonitorOwnerUIDLabel: "other-uid"},
+					},
+				})
+			})
+			It("should not take it over", func() {
+				// Act
+				err := routeMonitorAdder.EnsurePrometheusRuleResourceExists(ctx, routeMonitor, operatorConfig)
+				// Assert
+				Expect(err).To(MatchError(customerrors.ForeignResource))
+			})
+		})
 	})
 
 	Describe("New", func() {
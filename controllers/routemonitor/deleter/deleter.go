@@ -4,18 +4,23 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
 	"github.com/openshift/route-monitor-operator/controllers/routemonitor"
+	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
 	"github.com/openshift/route-monitor-operator/pkg/const/blackbox"
 
 	"context"
 	"errors"
 	"reflect"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 )
@@ -77,9 +82,108 @@ func (r *RouteMonitorDeleter) EnsureBlackBoxExporterServiceAbsent(ctx context.Co
 	return nil
 }
 
+func (r *RouteMonitorDeleter) EnsureBlackBoxExporterPodDisruptionBudgetAbsent(ctx context.Context) error {
+	resource := &policyv1beta1.PodDisruptionBudget{}
+
+	// Does the resource already exist?
+	err := r.Get(ctx, blackbox.BlackBoxNamespacedName, resource)
+	if err != nil {
+		// If this is an unknown error
+		if !k8serrors.IsNotFound(err) {
+			// return unexpectedly
+			return err
+		}
+		// Resource doesn't exist, nothing to do
+		return nil
+	}
+	err = r.Delete(ctx, resource)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *RouteMonitorDeleter) EnsureBlackBoxExporterNetworkPolicyAbsent(ctx context.Context) error {
+	resource := &networkingv1.NetworkPolicy{}
+
+	// Does the resource already exist?
+	err := r.Get(ctx, blackbox.BlackBoxNamespacedName, resource)
+	if err != nil {
+		// If this is an unknown error
+		if !k8serrors.IsNotFound(err) {
+			// return unexpectedly
+			return err
+		}
+		// Resource doesn't exist, nothing to do
+		return nil
+	}
+	err = r.Delete(ctx, resource)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *RouteMonitorDeleter) EnsureBlackBoxExporterServiceAccountAbsent(ctx context.Context) error {
+	resource := &corev1.ServiceAccount{}
+
+	// Does the resource already exist?
+	err := r.Get(ctx, blackbox.BlackBoxNamespacedName, resource)
+	if err != nil {
+		// If this is an unknown error
+		if !k8serrors.IsNotFound(err) {
+			// return unexpectedly
+			return err
+		}
+		// Resource doesn't exist, nothing to do
+		return nil
+	}
+	err = r.Delete(ctx, resource)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *RouteMonitorDeleter) EnsureBlackBoxExporterConfigMapAbsent(ctx context.Context) error {
+	resource := &corev1.ConfigMap{}
+
+	// Does the resource already exist?
+	err := r.Get(ctx, blackbox.BlackBoxNamespacedName, resource)
+	if err != nil {
+		// If this is an unknown error
+		if !k8serrors.IsNotFound(err) {
+			// return unexpectedly
+			return err
+		}
+		// Resource doesn't exist, nothing to do
+		return nil
+	}
+	err = r.Delete(ctx, resource)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// EnsureServiceMonitorResourceAbsent deletes the ServiceMonitor, the Probe and the PrometheusRule of the RouteMonitor, including the stale ones.
+// Both ServiceMonitors and Probes are deleted, as the backend may have changed since they were created
 func (r *RouteMonitorDeleter) EnsureServiceMonitorResourceAbsent(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) error {
+	if err := r.EnsureStaleServiceMonitorResourcesAbsent(ctx, routeMonitor); err != nil {
+		return err
+	}
+
 	namespacedName := routeMonitor.TemplateForServiceMonitorName()
-	resource := &monitoringv1.ServiceMonitor{}
+	for _, resource := range []runtime.Object{&monitoringv1.ServiceMonitor{}, &monitoringv1.Probe{}, &monitoringv1.PrometheusRule{}} {
+		if err := r.ensureOwnedResourceAbsent(ctx, routeMonitor, namespacedName, resource); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureOwnedResourceAbsent deletes the ServiceMonitor, Probe or PrometheusRule of the RouteMonitor, unless it belongs to another RouteMonitor
+func (r *RouteMonitorDeleter) ensureOwnedResourceAbsent(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, namespacedName types.NamespacedName, resource runtime.Object) error {
 	// Does the resource already exist?
 	err := r.Get(ctx, namespacedName, resource)
 	if err != nil {
@@ -91,6 +195,12 @@ func (r *RouteMonitorDeleter) EnsureServiceMonitorResourceAbsent(ctx context.Con
 		// Resource doesn't exist, nothing to do
 		return nil
 	}
+	// A resource of another RouteMonitor is left alone, as its RouteMonitor would stop being probed or alerted on
+	if meta, ok := resource.(metav1.Object); !ok || !routeMonitor.OwnsGeneratedResource(meta) {
+		kind := reflect.TypeOf(resource).Elem().Name()
+		r.Log.V(1).Info("Foreign "+kind+": not deleting the resource", "kind", kind, "resource", namespacedName.String())
+		return nil
+	}
 	err = r.Delete(ctx, resource)
 	if err != nil {
 		return err
@@ -98,6 +208,76 @@ func (r *RouteMonitorDeleter) EnsureServiceMonitorResourceAbsent(ctx context.Con
 	return nil
 }
 
+// EnsureStaleServiceMonitorResourcesAbsent deletes the ServiceMonitors and Probes the RouteMonitor no longer uses:
+// the one created under the name used before names were hashed, those left in another namespace when the placement changed,
+// and those of the backend the RouteMonitor doesn't use. The PrometheusRule is stale once the RouteMonitor has no alerting
+func (r *RouteMonitorDeleter) EnsureStaleServiceMonitorResourcesAbsent(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) error {
+	namespacedName := routeMonitor.TemplateForLegacyServiceMonitorName()
+	resource := &monitoringv1.ServiceMonitor{}
+	if err := r.Get(ctx, namespacedName, resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+	} else if isLegacyServiceMonitor(*resource) {
+		if err := client.IgnoreNotFound(r.Delete(ctx, resource)); err != nil {
+			return err
+		}
+	}
+
+	if routeMonitor.UID == "" {
+		return nil
+	}
+	serviceMonitors := &monitoringv1.ServiceMonitorList{}
+	if err := r.List(ctx, serviceMonitors, client.MatchingLabels(routeMonitor.TemplateForServiceMonitorOwnerLabels())); err != nil {
+		return err
+	}
+	probes := &monitoringv1.ProbeList{}
+	if err := r.List(ctx, probes, client.MatchingLabels(routeMonitor.TemplateForServiceMonitorOwnerLabels())); err != nil {
+		return err
+	}
+	prometheusRules := &monitoringv1.PrometheusRuleList{}
+	if err := r.List(ctx, prometheusRules, client.MatchingLabels(routeMonitor.TemplateForServiceMonitorOwnerLabels())); err != nil {
+		return err
+	}
+	current := routeMonitor.TemplateForServiceMonitorName()
+	backend := routeMonitor.GetBackend()
+	for _, serviceMonitor := range serviceMonitors.Items {
+		if backend == v1alpha1.BackendServiceMonitor && serviceMonitor.Namespace == current.Namespace && serviceMonitor.Name == current.Name {
+			continue
+		}
+		if err := client.IgnoreNotFound(r.Delete(ctx, serviceMonitor)); err != nil {
+			return err
+		}
+	}
+	for _, probe := range probes.Items {
+		if backend == v1alpha1.BackendProbe && probe.Namespace == current.Namespace && probe.Name == current.Name {
+			continue
+		}
+		if err := client.IgnoreNotFound(r.Delete(ctx, probe)); err != nil {
+			return err
+		}
+	}
+	for _, prometheusRule := range prometheusRules.Items {
+		if routeMonitor.Spec.Alerting != nil && prometheusRule.Namespace == current.Namespace && prometheusRule.Name == current.Name {
+			continue
+		}
+		if err := client.IgnoreNotFound(r.Delete(ctx, prometheusRule)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isLegacyServiceMonitor verifies that the ServiceMonitor was created by the operator before it tracked the RouteMonitor:
+// it has no owner and scrapes the blackbox exporter
+func isLegacyServiceMonitor(serviceMonitor monitoringv1.ServiceMonitor) bool {
+	if _, ok := serviceMonitor.Labels[routemonitorconst.ServiceMonitorOwnerUIDLabel]; ok {
+		return false
+	}
+	return serviceMonitor.Spec.JobLabel == serviceMonitor.Name &&
+		reflect.DeepEqual(serviceMonitor.Spec.Selector.MatchLabels, blackbox.GenerateBlackBoxLables())
+}
+
 func (r *RouteMonitorDeleter) ShouldDeleteBlackBoxExporterResources(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) (blackbox.ShouldDeleteBlackBoxExporter, error) {
 
 	// if a delete has not been requested then there is at least one resource using the BlackBoxExporter
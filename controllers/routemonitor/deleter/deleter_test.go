@@ -14,6 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/const"
 	"github.com/openshift/route-monitor-operator/pkg/const/blackbox"
 	consterror "github.com/openshift/route-monitor-operator/pkg/const/test/error"
 	constinit "github.com/openshift/route-monitor-operator/pkg/const/test/init"
@@ -21,6 +22,7 @@ import (
 	"github.com/openshift/route-monitor-operator/pkg/util/test/helper"
 
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openshift/route-monitor-operator/controllers/routemonitor"
@@ -138,6 +140,66 @@ var _ = Describe("Deleter", func() {
 		})
 
 	})
+	Describe("DeleteBlackBoxExporterConfigMap", func() {
+		BeforeEach(func() {
+			get.CalledTimes = 1
+			routeMonitorDeleterClient = mockClient
+		})
+
+		When("'Get' return an error", func() {
+			// Arrange
+			BeforeEach(func() {
+				get.ErrorResponse = consterror.CustomError
+			})
+			It("should bubble the error up", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterConfigMapAbsent(ctx)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+
+		When("'Get' return an 'NotFound' error", func() {
+			// Arrange
+			BeforeEach(func() {
+				get.ErrorResponse = consterror.NotFoundErr
+			})
+			It("should do nothing", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterConfigMapAbsent(ctx)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("'Delete' return an an  error", func() {
+			// Arrange
+			BeforeEach(func() {
+				delete = helper.CustomErrorHappensOnce()
+			})
+			It("should bubble the error up", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterConfigMapAbsent(ctx)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+
+		When("'Delete' succeeds", func() {
+			// Arrange
+			BeforeEach(func() {
+				delete.CalledTimes = 1
+			})
+			It("should succeed", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterConfigMapAbsent(ctx)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
 	Describe("DeleteBlackBoxExporterService", func() {
 		BeforeEach(func() {
 			get.CalledTimes = 1
@@ -200,61 +262,460 @@ var _ = Describe("Deleter", func() {
 
 	})
 
-	Describe("DeleteServiceMonitorResource", func() {
+	Describe("DeleteBlackBoxExporterPodDisruptionBudget", func() {
 		BeforeEach(func() {
 			get.CalledTimes = 1
 			routeMonitorDeleterClient = mockClient
+		})
 
+		When("'Get' return an error", func() {
+			// Arrange
+			BeforeEach(func() {
+				get.ErrorResponse = consterror.CustomError
+			})
+			It("should bubble the error up", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterPodDisruptionBudgetAbsent(ctx)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
 		})
-		When("'Get' returns an unhandled error", func() {
+
+		When("'Get' return an 'NotFound' error", func() {
+			// Arrange
+			BeforeEach(func() {
+				get.ErrorResponse = consterror.NotFoundErr
+			})
+			It("should do nothing", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterPodDisruptionBudgetAbsent(ctx)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("'Delete' return an an  error", func() {
+			// Arrange
+			BeforeEach(func() {
+				delete = helper.CustomErrorHappensOnce()
+			})
+			It("should do nothing", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterPodDisruptionBudgetAbsent(ctx)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+
+		When("'Delete' succeeds", func() {
+			// Arrange
+			BeforeEach(func() {
+				delete.CalledTimes = 1
+			})
+			It("should succeed", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterPodDisruptionBudgetAbsent(ctx)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+	})
+
+	Describe("DeleteBlackBoxExporterNetworkPolicy", func() {
+		BeforeEach(func() {
+			get.CalledTimes = 1
+			routeMonitorDeleterClient = mockClient
+		})
+
+		When("'Get' return an error", func() {
 			// Arrange
 			BeforeEach(func() {
 				get.ErrorResponse = consterror.CustomError
 			})
 			It("should bubble the error up", func() {
 				// Act
-				err := routeMonitorDeleter.EnsureServiceMonitorResourceAbsent(ctx, v1alpha1.RouteMonitor{})
+				err := routeMonitorDeleter.EnsureBlackBoxExporterNetworkPolicyAbsent(ctx)
 				// Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.CustomError))
 			})
 		})
-		When("'Get' returns an 'Not found' error", func() {
+
+		When("'Get' return an 'NotFound' error", func() {
 			// Arrange
 			BeforeEach(func() {
 				get.ErrorResponse = consterror.NotFoundErr
 			})
-			It("should succeed as there is nothing to delete", func() {
+			It("should do nothing", func() {
 				// Act
-				err := routeMonitorDeleter.EnsureServiceMonitorResourceAbsent(ctx, v1alpha1.RouteMonitor{})
+				err := routeMonitorDeleter.EnsureBlackBoxExporterNetworkPolicyAbsent(ctx)
 				// Assert
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
-		When("'Delete' returns an unhandled error", func() {
+
+		When("'Delete' return an an  error", func() {
 			// Arrange
 			BeforeEach(func() {
 				delete = helper.CustomErrorHappensOnce()
-				routeMonitorDeleterClient = mockClient
+			})
+			It("should do nothing", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterNetworkPolicyAbsent(ctx)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+
+		When("'Delete' succeeds", func() {
+			// Arrange
+			BeforeEach(func() {
+				delete.CalledTimes = 1
+			})
+			It("should succeed", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterNetworkPolicyAbsent(ctx)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+	})
+
+	Describe("DeleteBlackBoxExporterServiceAccount", func() {
+		BeforeEach(func() {
+			get.CalledTimes = 1
+			routeMonitorDeleterClient = mockClient
+		})
+
+		When("'Get' return an error", func() {
+			// Arrange
+			BeforeEach(func() {
+				get.ErrorResponse = consterror.CustomError
 			})
 			It("should bubble the error up", func() {
 				// Act
-				err := routeMonitorDeleter.EnsureServiceMonitorResourceAbsent(ctx, v1alpha1.RouteMonitor{})
+				err := routeMonitorDeleter.EnsureBlackBoxExporterServiceAccountAbsent(ctx)
 				// Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.CustomError))
 			})
 		})
-		When("'Delete' passes", func() {
+
+		When("'Get' return an 'NotFound' error", func() {
+			// Arrange
+			BeforeEach(func() {
+				get.ErrorResponse = consterror.NotFoundErr
+			})
+			It("should do nothing", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterServiceAccountAbsent(ctx)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("'Delete' return an an  error", func() {
+			// Arrange
+			BeforeEach(func() {
+				delete = helper.CustomErrorHappensOnce()
+			})
+			It("should do nothing", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterServiceAccountAbsent(ctx)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+
+		When("'Delete' succeeds", func() {
 			// Arrange
 			BeforeEach(func() {
 				delete.CalledTimes = 1
 			})
+			It("should succeed", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureBlackBoxExporterServiceAccountAbsent(ctx)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+	})
+
+	Describe("DeleteServiceMonitorResource", func() {
+		var (
+			serviceMonitorRouteMonitor v1alpha1.RouteMonitor
+			serviceMonitor             monitoringv1.ServiceMonitor
+		)
+		BeforeEach(func() {
+			serviceMonitorRouteMonitor = v1alpha1.RouteMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-name", Namespace: "fake-namespace", UID: "fake-uid"},
+			}
+			serviceMonitor = monitoringv1.ServiceMonitor{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceMonitorRouteMonitor.TemplateForServiceMonitorName().Name,
+					Namespace: blackbox.BlackBoxNamespace,
+					Labels:    serviceMonitorRouteMonitor.TemplateForServiceMonitorOwnerLabels(),
+				},
+			}
+		})
+		When("'Get' returns an unhandled error", func() {
+			// Arrange
+			BeforeEach(func() {
+				get = helper.CustomErrorHappensOnce()
+			})
+			It("should bubble the error up", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureServiceMonitorResourceAbsent(ctx, serviceMonitorRouteMonitor)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+		When("the ServiceMonitor does not exist", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorDeleterClient = fake.NewFakeClientWithScheme(constinit.Scheme)
+			})
+			It("should succeed as there is nothing to delete", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureServiceMonitorResourceAbsent(ctx, serviceMonitorRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("'Delete' returns an unhandled error", func() {
+			// Arrange
+			JustBeforeEach(func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(consterror.NotFoundErr),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, serviceMonitor).Return(nil),
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(consterror.CustomError),
+				)
+			})
+			It("should bubble the error up", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureServiceMonitorResourceAbsent(ctx, serviceMonitorRouteMonitor)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+		When("the ServiceMonitor belongs to the RouteMonitor", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorDeleterClient = fake.NewFakeClientWithScheme(constinit.Scheme, &serviceMonitor)
+			})
 			It("should succeed as the object was deleted", func() {
 				// Act
-				err := routeMonitorDeleter.EnsureServiceMonitorResourceAbsent(ctx, v1alpha1.RouteMonitor{})
+				err := routeMonitorDeleter.EnsureServiceMonitorResourceAbsent(ctx, serviceMonitorRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitorDeleter.Get(ctx, serviceMonitorRouteMonitor.TemplateForServiceMonitorName(), &monitoringv1.ServiceMonitor{})).
+					To(MatchError(ContainSubstring("not found")))
+			})
+		})
+		When("the RouteMonitor is scraped by a Probe", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorDeleterClient = fake.NewFakeClientWithScheme(constinit.Scheme, &monitoringv1.Probe{
+					ObjectMeta: serviceMonitor.ObjectMeta,
+				})
+			})
+			It("should delete the Probe", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureServiceMonitorResourceAbsent(ctx, serviceMonitorRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitorDeleter.Get(ctx, serviceMonitorRouteMonitor.TemplateForServiceMonitorName(), &monitoringv1.Probe{})).
+					To(MatchError(ContainSubstring("not found")))
+			})
+		})
+		When("the RouteMonitor has a PrometheusRule", func() {
+			// Arrange
+			BeforeEach(func() {
+				serviceMonitorRouteMonitor.Spec.Alerting = &v1alpha1.RouteMonitorAlerting{}
+				routeMonitorDeleterClient = fake.NewFakeClientWithScheme(constinit.Scheme, &serviceMonitor, &monitoringv1.PrometheusRule{
+					ObjectMeta: serviceMonitor.ObjectMeta,
+				})
+			})
+			It("should delete the PrometheusRule", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureServiceMonitorResourceAbsent(ctx, serviceMonitorRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitorDeleter.Get(ctx, serviceMonitorRouteMonitor.TemplateForServiceMonitorName(), &monitoringv1.PrometheusRule{})).
+					To(MatchError(ContainSubstring("not found")))
+			})
+		})
+		When("the ServiceMonitor belongs to another RouteMonitor", func() {
+			// Arrange
+			BeforeEach(func() {
+				serviceMonitor.Labels = map[string]string{routemonitorconst.ServiceMonitorOwnerUIDLabel: "other-uid"}
+				routeMonitorDeleterClient = fake.NewFakeClientWithScheme(constinit.Scheme, &serviceMonitor)
+			})
+			It("should leave the ServiceMonitor alone", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureServiceMonitorResourceAbsent(ctx, serviceMonitorRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitorDeleter.Get(ctx, serviceMonitorRouteMonitor.TemplateForServiceMonitorName(), &monitoringv1.ServiceMonitor{})).To(Succeed())
+			})
+		})
+	})
+	Describe("EnsureStaleServiceMonitorResourcesAbsent", func() {
+		var (
+			legacyRouteMonitor   v1alpha1.RouteMonitor
+			legacyServiceMonitor monitoringv1.ServiceMonitor
+		)
+		BeforeEach(func() {
+			legacyRouteMonitor = v1alpha1.RouteMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-name", Namespace: "fake-namespace", UID: "fake-uid"},
+			}
+			legacyServiceMonitor = monitoringv1.ServiceMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-name-fake-namespace", Namespace: blackbox.BlackBoxNamespace},
+				Spec: monitoringv1.ServiceMonitorSpec{
+					JobLabel: "fake-name-fake-namespace",
+					Selector: metav1.LabelSelector{MatchLabels: blackbox.GenerateBlackBoxLables()},
+				},
+			}
+		})
+		JustBeforeEach(func() {
+			routeMonitorDeleterClient = fake.NewFakeClientWithScheme(constinit.Scheme, &legacyServiceMonitor)
+			routeMonitorDeleter.Client = routeMonitorDeleterClient
+		})
+		When("the ServiceMonitor was created under the legacy name", func() {
+			It("should delete it", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureStaleServiceMonitorResourcesAbsent(ctx, legacyRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitorDeleter.Get(ctx, legacyRouteMonitor.TemplateForLegacyServiceMonitorName(), &monitoringv1.ServiceMonitor{})).
+					To(MatchError(ContainSubstring("not found")))
+			})
+		})
+		When("the ServiceMonitor under the legacy name was not created by the operator", func() {
+			BeforeEach(func() {
+				legacyServiceMonitor.Spec.Selector = metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}}
+			})
+			It("should leave it alone", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureStaleServiceMonitorResourcesAbsent(ctx, legacyRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitorDeleter.Get(ctx, legacyRouteMonitor.TemplateForLegacyServiceMonitorName(), &monitoringv1.ServiceMonitor{})).To(Succeed())
+			})
+		})
+		When("a ServiceMonitor of the RouteMonitor was left in another namespace", func() {
+			var stale monitoringv1.ServiceMonitor
+			BeforeEach(func() {
+				stale = monitoringv1.ServiceMonitor{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      legacyRouteMonitor.TemplateForServiceMonitorName().Name,
+						Namespace: legacyRouteMonitor.Namespace,
+						Labels:    legacyRouteMonitor.TemplateForServiceMonitorOwnerLabels(),
+					},
+				}
+			})
+			JustBeforeEach(func() {
+				current := stale.DeepCopy()
+				current.Namespace = blackbox.BlackBoxNamespace
+				routeMonitorDeleter.Client = fake.NewFakeClientWithScheme(constinit.Scheme, &stale, current)
+			})
+			It("should delete it and keep the current one", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureStaleServiceMonitorResourcesAbsent(ctx, legacyRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				serviceMonitors := monitoringv1.ServiceMonitorList{}
+				Expect(routeMonitorDeleter.List(ctx, &serviceMonitors)).To(Succeed())
+				Expect(serviceMonitors.Items).To(HaveLen(1))
+				Expect(serviceMonitors.Items[0].Namespace).To(Equal(blackbox.BlackBoxNamespace))
+			})
+		})
+		When("the backend of the RouteMonitor changed", func() {
+			var current metav1.ObjectMeta
+			BeforeEach(func() {
+				current = metav1.ObjectMeta{
+					Name:      legacyRouteMonitor.TemplateForServiceMonitorName().Name,
+					Namespace: blackbox.BlackBoxNamespace,
+					Labels:    legacyRouteMonitor.TemplateForServiceMonitorOwnerLabels(),
+				}
+			})
+			JustBeforeEach(func() {
+				routeMonitorDeleter.Client = fake.NewFakeClientWithScheme(constinit.Scheme,
+					&monitoringv1.ServiceMonitor{ObjectMeta: current}, &monitoringv1.Probe{ObjectMeta: current})
+			})
+			It("should delete the ServiceMonitor when the Probe backend is used", func() {
+				// Arrange
+				legacyRouteMonitor.Spec.Backend = v1alpha1.BackendProbe
+				// Act
+				err := routeMonitorDeleter.EnsureStaleServiceMonitorResourcesAbsent(ctx, legacyRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				namespacedName := legacyRouteMonitor.TemplateForServiceMonitorName()
+				Expect(routeMonitorDeleter.Get(ctx, namespacedName, &monitoringv1.ServiceMonitor{})).To(MatchError(ContainSubstring("not found")))
+				Expect(routeMonitorDeleter.Get(ctx, namespacedName, &monitoringv1.Probe{})).To(Succeed())
+			})
+			It("should delete the Probe when the ServiceMonitor backend is used", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureStaleServiceMonitorResourcesAbsent(ctx, legacyRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				namespacedName := legacyRouteMonitor.TemplateForServiceMonitorName()
+				Expect(routeMonitorDeleter.Get(ctx, namespacedName, &monitoringv1.ServiceMonitor{})).To(Succeed())
+				Expect(routeMonitorDeleter.Get(ctx, namespacedName, &monitoringv1.Probe{})).To(MatchError(ContainSubstring("not found")))
+			})
+		})
+		When("the RouteMonitor has a PrometheusRule", func() {
+			var current metav1.ObjectMeta
+			BeforeEach(func() {
+				current = metav1.ObjectMeta{
+					Name:      legacyRouteMonitor.TemplateForServiceMonitorName().Name,
+					Namespace: blackbox.BlackBoxNamespace,
+					Labels:    legacyRouteMonitor.TemplateForServiceMonitorOwnerLabels(),
+				}
+			})
+			JustBeforeEach(func() {
+				routeMonitorDeleter.Client = fake.NewFakeClientWithScheme(constinit.Scheme, &monitoringv1.PrometheusRule{ObjectMeta: current})
+			})
+			It("should keep it while alerting is configured", func() {
+				// Arrange
+				legacyRouteMonitor.Spec.Alerting = &v1alpha1.RouteMonitorAlerting{}
+				// Act
+				err := routeMonitorDeleter.EnsureStaleServiceMonitorResourcesAbsent(ctx, legacyRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitorDeleter.Get(ctx, legacyRouteMonitor.TemplateForServiceMonitorName(), &monitoringv1.PrometheusRule{})).To(Succeed())
+			})
+			It("should delete it once alerting was removed", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureStaleServiceMonitorResourcesAbsent(ctx, legacyRouteMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitorDeleter.Get(ctx, legacyRouteMonitor.TemplateForServiceMonitorName(), &monitoringv1.PrometheusRule{})).
+					To(MatchError(ContainSubstring("not found")))
+			})
+		})
+		When("the ServiceMonitor under the legacy name belongs to another RouteMonitor", func() {
+			BeforeEach(func() {
+				legacyServiceMonitor.Labels = map[string]string{routemonitorconst.ServiceMonitorOwnerUIDLabel: "other-uid"}
+			})
+			It("should leave it alone", func() {
+				// Act
+				err := routeMonitorDeleter.EnsureStaleServiceMonitorResourcesAbsent(ctx, legacyRouteMonitor)
 				// Assert
 				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMonitorDeleter.Get(ctx, legacyRouteMonitor.TemplateForLegacyServiceMonitorName(), &monitoringv1.ServiceMonitor{})).To(Succeed())
 			})
 		})
 	})
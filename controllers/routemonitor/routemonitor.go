@@ -18,13 +18,33 @@ package routemonitor
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	monitoringv1alpha1 "github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/pkg/const/blackbox"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 )
 
@@ -38,12 +58,19 @@ type RouteMonitorReconciler struct {
 	RouteMonitorDeleter
 }
 
-// +kubebuilder:rbac:groups=*,resources=services,verbs=get;list;watch;create
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create
-// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=*,resources=services,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=*,resources=configmaps,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=*,resources=serviceaccounts,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=probes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.openshift.io,resources=routemonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.openshift.io,resources=routemonitors/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=routemonitoroperatorconfigs,verbs=get;list;watch
 
 func (r *RouteMonitorReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	ctx := context.Background()
@@ -75,41 +102,273 @@ func (r *RouteMonitorReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error
 		return utilreconcile.Stop()
 	}
 
+	// The conditions are set in memory by every step and written once, the status as it was fetched tells whether a write is needed
+	fetchedStatus := *routeMonitor.Status.DeepCopy()
+
+	log.V(2).Info("Entering GetOperatorConfig")
+	operatorConfig, err := r.GetOperatorConfig(ctx)
+	if err != nil {
+		return r.failReconcile(ctx, routeMonitor, fetchedStatus, v1alpha1.ConditionBlackboxExporterReady, err)
+	}
+	// the defaults are only applied in memory, so the RouteMonitor must not be written back with an Update
+	routeMonitor.ApplyProbeDefaults(operatorConfig.ProbeDefaults)
+	routeMonitor.Status.EffectiveProbe = routeMonitor.TemplateForEffectiveProbe()
+
 	log.V(2).Info("Entering CreateBlackBoxExporterResources")
 	// Should happen once but cannot input in main.go
-	err = r.EnsureBlackBoxExporterResourcesExists(ctx)
+	err = r.EnsureBlackBoxExporterResourcesExists(ctx, operatorConfig)
 	if err != nil {
-		return utilreconcile.RequeueWith(err)
+		return r.failReconcile(ctx, routeMonitor, fetchedStatus, v1alpha1.ConditionBlackboxExporterReady, err)
 	}
+	routeMonitor.SetCondition(v1alpha1.ConditionBlackboxExporterReady, metav1.ConditionTrue, v1alpha1.ReasonResourcesExist, "The blackbox exporter resources exist")
 
-	log.V(2).Info("Entering GetRoute")
-	route, err := r.GetRoute(ctx, routeMonitor)
+	log.V(2).Info("Entering GetRoutes")
+	routes, err := r.GetRoutes(ctx, routeMonitor)
 	if err != nil {
-		return utilreconcile.RequeueWith(err)
+		return r.failReconcile(ctx, routeMonitor, fetchedStatus, v1alpha1.ConditionRouteFound, err)
+	}
+	routeFoundMessage := fmt.Sprintf("Found %d Route(s)", len(routes))
+	if routeMonitor.Spec.URL != "" {
+		routeFoundMessage = "The url of the spec is probed without a Route"
 	}
+	routeMonitor.SetCondition(v1alpha1.ConditionRouteFound, metav1.ConditionTrue, v1alpha1.ReasonRouteFound, routeFoundMessage)
 
 	log.V(2).Info("Entering UpdateRouteURL")
-	res, err = r.EnsureRouteURLExists(ctx, route, routeMonitor)
+	res, err = r.EnsureRouteURLExists(ctx, routes, routeMonitor)
 	if err != nil {
-		return utilreconcile.RequeueWith(err)
+		return r.failReconcile(ctx, routeMonitor, fetchedStatus, v1alpha1.ConditionURLResolved, err)
 	}
 	if res.ShouldStop() {
+		// the status was written together with the urls, the next reconcile sets the remaining conditions
 		return utilreconcile.Stop()
 	}
+	routeMonitor.SetCondition(v1alpha1.ConditionURLResolved, metav1.ConditionTrue, v1alpha1.ReasonURLResolved, fmt.Sprintf("Resolved %d url(s)", len(routeMonitor.Status.RouteURLs)))
 
 	log.V(2).Info("Entering CreateServiceMonitorResource")
-	res, err = r.EnsureServiceMonitorResourceExists(ctx, routeMonitor)
+	res, err = r.EnsureServiceMonitorResourceExists(ctx, routeMonitor, operatorConfig)
 	if err != nil {
-		return utilreconcile.RequeueWith(err)
+		return r.failReconcile(ctx, routeMonitor, fetchedStatus, v1alpha1.ConditionServiceMonitorReady, err)
 	}
 	if res.ShouldStop() {
 		return utilreconcile.Stop()
 	}
+
+	log.V(2).Info("Entering EnsureStaleServiceMonitorResourcesAbsent")
+	if err := r.EnsureStaleServiceMonitorResourcesAbsent(ctx, routeMonitor); err != nil {
+		return r.failReconcile(ctx, routeMonitor, fetchedStatus, v1alpha1.ConditionServiceMonitorReady, err)
+	}
+
+	log.V(2).Info("Entering EnsureServiceMonitorResourceUpToDate")
+	patched, err := r.EnsureServiceMonitorResourceUpToDate(ctx, routeMonitor, operatorConfig)
+	if err != nil {
+		return r.failReconcile(ctx, routeMonitor, fetchedStatus, v1alpha1.ConditionServiceMonitorReady, err)
+	}
+
+	setServiceMonitorReadyCondition(&routeMonitor, patched)
+
+	log.V(2).Info("Entering EnsureAlertingReady")
+	if err := r.EnsureAlertingReady(ctx, &routeMonitor, operatorConfig); err != nil {
+		return r.failReconcile(ctx, routeMonitor, fetchedStatus, v1alpha1.ConditionAlertingReady, err)
+	}
+
+	log.V(2).Info("Entering EnsureStatusUpdated")
+	if err := r.EnsureStatusUpdated(ctx, routeMonitor, fetchedStatus); err != nil {
+		return utilreconcile.RequeueWith(err)
+	}
 	return utilreconcile.Stop()
 }
 
+// setServiceMonitorReadyCondition marks the ServiceMonitor, or the Probe of the Probe backend, as ready.
+// A patch of the ServiceMonitor is recorded in the condition until the ServiceMonitor fails or is patched again
+func setServiceMonitorReadyCondition(routeMonitor *v1alpha1.RouteMonitor, patched bool) {
+	kind := routeMonitor.GetBackend()
+	reason := v1alpha1.ReasonResourcesExist
+	message := fmt.Sprintf("The %s probing the Route exists", kind)
+	condition := v1alpha1.FindCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionServiceMonitorReady)
+	if patched {
+		reason = v1alpha1.ReasonServiceMonitorPatched
+		message = fmt.Sprintf("The %s was patched to probe %d url(s) with the current settings at %s", kind, len(routeMonitor.Status.RouteURLs), metav1.Now().UTC().Format(time.RFC3339))
+	} else if condition != nil && condition.Status == metav1.ConditionTrue && condition.Reason == v1alpha1.ReasonServiceMonitorPatched {
+		reason = condition.Reason
+		message = condition.Message
+	}
+	routeMonitor.SetCondition(v1alpha1.ConditionServiceMonitorReady, metav1.ConditionTrue, reason, message)
+}
+
+// EnsureAlertingReady ensures the PrometheusRule of a RouteMonitor with alerting and records the outcome on AlertingReady.
+// A failing PrometheusRule doesn't stop the probes, so it is kept off ServiceMonitorReady
+func (r *RouteMonitorReconciler) EnsureAlertingReady(ctx context.Context, routeMonitor *v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) error {
+	if err := r.EnsurePrometheusRuleResourceExists(ctx, *routeMonitor, config); err != nil {
+		routeMonitor.SetCondition(v1alpha1.ConditionAlertingReady, metav1.ConditionFalse, reasonForError(v1alpha1.ConditionAlertingReady, err), err.Error())
+		return err
+	}
+	if routeMonitor.Spec.Alerting == nil {
+		routeMonitor.SetCondition(v1alpha1.ConditionAlertingReady, metav1.ConditionTrue, v1alpha1.ReasonAlertingDisabled, "The RouteMonitor has no alerting")
+		return nil
+	}
+	routeMonitor.SetCondition(v1alpha1.ConditionAlertingReady, metav1.ConditionTrue, v1alpha1.ReasonResourcesExist, "The PrometheusRule alerting on the probes exists")
+	return nil
+}
+
+// failReconcile records the error of a step on its condition before bubbling it up
+func (r *RouteMonitorReconciler) failReconcile(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, fetchedStatus v1alpha1.RouteMonitorStatus, conditionType string, err error) (ctrl.Result, error) {
+	routeMonitor.SetCondition(conditionType, metav1.ConditionFalse, reasonForError(conditionType, err), err.Error())
+	if statusErr := r.EnsureStatusUpdated(ctx, routeMonitor, fetchedStatus); statusErr != nil {
+		// the error of the step is more relevant, the conditions are written on the retry
+		r.Log.Error(statusErr, "Failed to update the conditions", "condition", conditionType)
+	}
+	return utilreconcile.RequeueWith(err)
+}
+
+// reasonForError maps the errors of the reconcile steps to the reason of a condition.
+// Only the lookup of the Route reports a missing resource as a missing Route, the other steps fail on resources of their own
+func reasonForError(conditionType string, err error) string {
+	switch {
+	case conditionType == v1alpha1.ConditionRouteFound && k8serrors.IsNotFound(err), errors.Is(err, customerrors.NoRoute):
+		return v1alpha1.ReasonRouteNotFound
+	case errors.Is(err, customerrors.NoIngress):
+		return v1alpha1.ReasonNoAdmittedIngress
+	case errors.Is(err, customerrors.NoHost):
+		return v1alpha1.ReasonNoHost
+	case errors.Is(err, customerrors.ForeignResource):
+		return v1alpha1.ReasonResourceForeign
+	case errors.Is(err, customerrors.InvalidCR):
+		return v1alpha1.ReasonInvalidSpec
+	default:
+		return v1alpha1.ReasonReconcileFailed
+	}
+}
+
+// RouteIndexField indexes the RouteMonitors by the namespace/name of the Route they reference
+const RouteIndexField = "spec.route"
+
+// IndexRouteMonitorByRoute returns the values of the RouteIndexField of a RouteMonitor
+func IndexRouteMonitorByRoute(obj runtime.Object) []string {
+	routeMonitor, ok := obj.(*v1alpha1.RouteMonitor)
+	if !ok {
+		return nil
+	}
+	if key := routeMonitor.GetRouteKey(); key != "" {
+		return []string{key}
+	}
+	return nil
+}
+
 func (r *RouteMonitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.RouteMonitor{}, RouteIndexField, IndexRouteMonitorByRoute); err != nil {
+		return err
+	}
+	// The generated resources live in the namespace of the exporter and cannot be owned by the RouteMonitors,
+	// so they are matched by their labels and mapped back to the RouteMonitors to recreate them when they are deleted
+	toAllRouteMonitors := &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(r.requestsForAllRouteMonitors),
+	}
+	blackBoxExporterPredicates := builder.WithPredicates(predicate.NewPredicateFuncs(isBlackBoxExporterResource), specChangedPredicate)
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&monitoringv1alpha1.RouteMonitor{}).
+		For(&v1alpha1.RouteMonitor{}).
+		Watches(&source.Kind{Type: &routev1.Route{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.requestsForRoute),
+		}).
+		Watches(&source.Kind{Type: &monitoringv1.ServiceMonitor{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(requestsForServiceMonitor),
+		}, builder.WithPredicates(specChangedPredicate)).
+		Watches(&source.Kind{Type: &monitoringv1.Probe{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(requestsForServiceMonitor),
+		}, builder.WithPredicates(specChangedPredicate)).
+		Watches(&source.Kind{Type: &monitoringv1.PrometheusRule{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(requestsForServiceMonitor),
+		}, builder.WithPredicates(specChangedPredicate)).
+		Watches(&source.Kind{Type: &appsv1.Deployment{}}, toAllRouteMonitors, blackBoxExporterPredicates).
+		Watches(&source.Kind{Type: &corev1.Service{}}, toAllRouteMonitors, blackBoxExporterPredicates).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, toAllRouteMonitors, blackBoxExporterPredicates).
+		Watches(&source.Kind{Type: &corev1.ServiceAccount{}}, toAllRouteMonitors, blackBoxExporterPredicates).
+		Watches(&source.Kind{Type: &policyv1beta1.PodDisruptionBudget{}}, toAllRouteMonitors, blackBoxExporterPredicates).
+		Watches(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, toAllRouteMonitors, blackBoxExporterPredicates).
+		Watches(&source.Kind{Type: &v1alpha1.RouteMonitorOperatorConfig{}}, toAllRouteMonitors).
 		Complete(r)
 }
+
+// specChangedPredicate skips the status updates of the generated resources, e.g. of the exporter Deployment during a rollout.
+// Resources without a generation, like Services, pass every update
+var specChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.MetaNew.GetGeneration() == 0 || e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+	},
+}
+
+// isBlackBoxExporterResource verifies that the object is one of the exporter resources the operator generates
+func isBlackBoxExporterResource(meta metav1.Object, _ runtime.Object) bool {
+	if meta.GetNamespace() != blackbox.BlackBoxNamespace || meta.GetName() != blackbox.BlackBoxName {
+		return false
+	}
+	for key, value := range blackbox.GenerateBlackBoxLables() {
+		if meta.GetLabels()[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// requestsForServiceMonitor maps a generated ServiceMonitor to the RouteMonitor it was created for
+func requestsForServiceMonitor(obj handler.MapObject) []reconcile.Request {
+	owner, ok := v1alpha1.RouteMonitorOfServiceMonitor(obj.Meta)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: owner}}
+}
+
+// requestsForAllRouteMonitors enqueues every RouteMonitor, as they all depend on the exporter
+func (r *RouteMonitorReconciler) requestsForAllRouteMonitors(_ handler.MapObject) []reconcile.Request {
+	routeMonitors := v1alpha1.RouteMonitorList{}
+	if err := r.List(context.Background(), &routeMonitors); err != nil {
+		r.Log.Error(err, "Failed to list the RouteMonitors")
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, routeMonitor := range routeMonitors.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      routeMonitor.Name,
+			Namespace: routeMonitor.Namespace,
+		}})
+	}
+	return requests
+}
+
+// requestsForRoute maps a Route to the RouteMonitors that reference it by name or whose RouteSelector matches it,
+// so they pick up Routes that are created after them, readmitted with another host, relabelled or removed
+func (r *RouteMonitorReconciler) requestsForRoute(obj handler.MapObject) []reconcile.Request {
+	ctx := context.Background()
+	routeKey := types.NamespacedName{Namespace: obj.Meta.GetNamespace(), Name: obj.Meta.GetName()}
+	requests := []reconcile.Request{}
+	enqueued := map[types.NamespacedName]bool{}
+	enqueue := func(routeMonitor v1alpha1.RouteMonitor) {
+		namespacedName := types.NamespacedName{Name: routeMonitor.Name, Namespace: routeMonitor.Namespace}
+		if enqueued[namespacedName] {
+			return
+		}
+		enqueued[namespacedName] = true
+		requests = append(requests, reconcile.Request{NamespacedName: namespacedName})
+	}
+
+	referencing := v1alpha1.RouteMonitorList{}
+	if err := r.List(ctx, &referencing, client.MatchingFields{RouteIndexField: routeKey.String()}); err != nil {
+		r.Log.Error(err, "Failed to list the RouteMonitors referencing a Route", "route", routeKey.String())
+		return nil
+	}
+	for _, routeMonitor := range referencing.Items {
+		enqueue(routeMonitor)
+	}
+
+	selecting := v1alpha1.RouteMonitorList{}
+	if err := r.List(ctx, &selecting, client.InNamespace(routeKey.Namespace)); err != nil {
+		r.Log.Error(err, "Failed to list the RouteMonitors for a Route", "route", routeKey.String())
+		return nil
+	}
+	for _, routeMonitor := range selecting.Items {
+		if routeMonitor.SelectsRoute(routeKey.Namespace, obj.Meta.GetLabels()) {
+			enqueue(routeMonitor)
+		}
+	}
+	return requests
+}
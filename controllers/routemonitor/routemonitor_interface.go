@@ -14,9 +14,10 @@ import (
 //go:generate mockgen -source $GOFILE -destination ../../pkg/util/test/generated/mocks/$GOPACKAGE/routemonitor.go -package $GOPACKAGE RouteMonitorActionDoer,RouteMonitorDeleter,RouteMonitorAdder
 
 type RouteMonitorSupplement interface {
+	GetOperatorConfig(ctx context.Context) (v1alpha1.RouteMonitorOperatorConfigSpec, error)
 	GetRouteMonitor(ctx context.Context, req ctrl.Request) (routeMonitor v1alpha1.RouteMonitor, res utilreconcile.Result, err error)
-	GetRoute(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) (routev1.Route, error)
-	EnsureRouteURLExists(ctx context.Context, route routev1.Route, routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error)
+	GetRoutes(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) ([]routev1.Route, error)
+	EnsureRouteURLExists(ctx context.Context, routes []routev1.Route, routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error)
 	EnsureFinalizerAbsent(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error)
 }
 
@@ -24,11 +25,22 @@ type RouteMonitorDeleter interface {
 	ShouldDeleteBlackBoxExporterResources(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) (blackbox.ShouldDeleteBlackBoxExporter, error)
 	EnsureBlackBoxExporterDeploymentAbsent(ctx context.Context) error
 	EnsureBlackBoxExporterServiceAbsent(ctx context.Context) error
+	EnsureBlackBoxExporterPodDisruptionBudgetAbsent(ctx context.Context) error
+	EnsureBlackBoxExporterNetworkPolicyAbsent(ctx context.Context) error
+	EnsureBlackBoxExporterServiceAccountAbsent(ctx context.Context) error
+	EnsureBlackBoxExporterConfigMapAbsent(ctx context.Context) error
 	EnsureServiceMonitorResourceAbsent(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) error
+	EnsureStaleServiceMonitorResourcesAbsent(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) error
 }
 
 type RouteMonitorAdder interface {
-	EnsureBlackBoxExporterDeploymentExists(ctx context.Context) error
+	EnsureBlackBoxExporterConfigMapExists(ctx context.Context, config v1alpha1.RouteMonitorOperatorConfigSpec) error
+	EnsureBlackBoxExporterServiceAccountExists(ctx context.Context) error
+	EnsureBlackBoxExporterDeploymentExists(ctx context.Context, config v1alpha1.RouteMonitorOperatorConfigSpec) error
 	EnsureBlackBoxExporterServiceExists(ctx context.Context) error
-	EnsureServiceMonitorResourceExists(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error)
+	EnsureBlackBoxExporterPodDisruptionBudgetExists(ctx context.Context) error
+	EnsureBlackBoxExporterNetworkPolicyExists(ctx context.Context, config v1alpha1.RouteMonitorOperatorConfigSpec) error
+	EnsureServiceMonitorResourceExists(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) (utilreconcile.Result, error)
+	EnsureServiceMonitorResourceUpToDate(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) (bool, error)
+	EnsurePrometheusRuleResourceExists(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) error
 }
@@ -2,13 +2,24 @@ package routemonitor
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 )
 
-func (r *RouteMonitorReconciler) EnsureBlackBoxExporterResourcesExists(ctx context.Context) error {
-	if err := r.EnsureBlackBoxExporterDeploymentExists(ctx); err != nil {
+func (r *RouteMonitorReconciler) EnsureBlackBoxExporterResourcesExists(ctx context.Context, config v1alpha1.RouteMonitorOperatorConfigSpec) error {
+	// Creating ConfigMap first because:
+	//
+	// The Deployment mounts it and rolls whenever its content changes
+	if err := r.EnsureBlackBoxExporterConfigMapExists(ctx, config); err != nil {
+		return err
+	}
+	// The Deployment runs as the ServiceAccount, kube-rbac-proxy reviews the tokens with it
+	if err := r.EnsureBlackBoxExporterServiceAccountExists(ctx); err != nil {
+		return err
+	}
+	if err := r.EnsureBlackBoxExporterDeploymentExists(ctx, config); err != nil {
 		return err
 	}
 	// Creating Service after because:
@@ -17,6 +28,14 @@ func (r *RouteMonitorReconciler) EnsureBlackBoxExporterResourcesExists(ctx conte
 	if err := r.EnsureBlackBoxExporterServiceExists(ctx); err != nil {
 		return err
 	}
+	// The PodDisruptionBudget keeps a node drain from evicting every exporter at once
+	if err := r.EnsureBlackBoxExporterPodDisruptionBudgetExists(ctx); err != nil {
+		return err
+	}
+	// The NetworkPolicy keeps the exporter from being used to reach arbitrary urls by anyone but Prometheus
+	if err := r.EnsureBlackBoxExporterNetworkPolicyExists(ctx, config); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -69,10 +88,26 @@ func (r *RouteMonitorReconciler) ensureBlackBoxExporterResourcesAbsent(ctx conte
 	if err := r.EnsureBlackBoxExporterServiceAbsent(ctx); err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
+	r.Log.V(2).Info("Entering EnsureBlackBoxExporterPodDisruptionBudgetAbsent")
+	if err := r.EnsureBlackBoxExporterPodDisruptionBudgetAbsent(ctx); err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
+	}
+	r.Log.V(2).Info("Entering EnsureBlackBoxExporterNetworkPolicyAbsent")
+	if err := r.EnsureBlackBoxExporterNetworkPolicyAbsent(ctx); err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
+	}
 	r.Log.V(2).Info("Entering EnsureBlackBoxExporterDeploymentAbsent")
 	if err := r.EnsureBlackBoxExporterDeploymentAbsent(ctx); err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
+	r.Log.V(2).Info("Entering EnsureBlackBoxExporterServiceAccountAbsent")
+	if err := r.EnsureBlackBoxExporterServiceAccountAbsent(ctx); err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
+	}
+	r.Log.V(2).Info("Entering EnsureBlackBoxExporterConfigMapAbsent")
+	if err := r.EnsureBlackBoxExporterConfigMapAbsent(ctx); err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
+	}
 	return utilreconcile.ContinueReconcile()
 }
 
@@ -88,3 +123,15 @@ func (r *RouteMonitorReconciler) ensureServiceMonitoRelatedResourcesrAbsent(ctx
 	}
 	return utilreconcile.ContinueReconcile()
 }
+
+// EnsureStatusUpdated writes the conditions set during the reconcile, skipping the write when nothing changed since fetchedStatus
+func (r *RouteMonitorReconciler) EnsureStatusUpdated(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, fetchedStatus v1alpha1.RouteMonitorStatus) error {
+	routeMonitor.SetReadyCondition()
+	routeMonitor.Status.ObservedGeneration = routeMonitor.Generation
+
+	if reflect.DeepEqual(routeMonitor.Status, fetchedStatus) {
+		r.Log.V(3).Info("Same Status: the conditions did not change, update not required")
+		return nil
+	}
+	return r.Status().Update(ctx, &routeMonitor)
+}
@@ -1,6 +1,7 @@
 package routemonitor_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/golang/mock/gomock"
@@ -19,6 +20,7 @@ import (
 	"github.com/openshift/route-monitor-operator/pkg/const/blackbox"
 	consterror "github.com/openshift/route-monitor-operator/pkg/const/test/error"
 	constinit "github.com/openshift/route-monitor-operator/pkg/const/test/init"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 	clientmocks "github.com/openshift/route-monitor-operator/pkg/util/test/generated/mocks/client"
 	routemonitormocks "github.com/openshift/route-monitor-operator/pkg/util/test/generated/mocks/routemonitor"
@@ -30,8 +32,9 @@ import (
 var _ = Describe("Routemonitor", func() {
 
 	var (
-		mockClient *clientmocks.MockClient
-		mockCtrl   *gomock.Controller
+		mockClient       *clientmocks.MockClient
+		mockStatusWriter *clientmocks.MockStatusWriter
+		mockCtrl         *gomock.Controller
 
 		routeMonitorReconciler       routemonitor.RouteMonitorReconciler
 		routeMonitorReconcilerClient client.Client
@@ -47,9 +50,17 @@ var _ = Describe("Routemonitor", func() {
 		ensureServiceMonitorResourceAbsent     helper.MockHelper
 		shouldDeleteBlackBoxExporterResources  helper.MockHelper //blackbox.ShouldDeleteBlackBoxExporter
 		ensureBlackBoxExporterServiceAbsent    helper.MockHelper
+		ensureBlackBoxExporterPDBAbsent        helper.MockHelper
+		ensureBlackBoxExporterNPAbsent         helper.MockHelper
+		ensureBlackBoxExporterSAAbsent         helper.MockHelper
 		ensureBlackBoxExporterDeploymentAbsent helper.MockHelper
+		ensureBlackBoxExporterConfigMapAbsent  helper.MockHelper
+		ensureBlackBoxExporterConfigMapExists  helper.MockHelper
+		ensureBlackBoxExporterSAExists         helper.MockHelper
 		ensureBlackBoxExporterDeploymentExists helper.MockHelper
 		ensureBlackBoxExporterServiceExists    helper.MockHelper
+		ensureBlackBoxExporterPDBExists        helper.MockHelper
+		ensureBlackBoxExporterNPExists         helper.MockHelper
 		ensureFinalizerAbsent                  helper.MockHelper // utilreconcile.Result
 
 		shouldDeleteBlackBoxExporterResourcesResponse blackbox.ShouldDeleteBlackBoxExporter
@@ -64,6 +75,7 @@ var _ = Describe("Routemonitor", func() {
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockClient = clientmocks.NewMockClient(mockCtrl)
+		mockStatusWriter = clientmocks.NewMockStatusWriter(mockCtrl)
 		mockDeleter = routemonitormocks.NewMockRouteMonitorDeleter(mockCtrl)
 		mockAdder = routemonitormocks.NewMockRouteMonitorAdder(mockCtrl)
 
@@ -81,9 +93,17 @@ var _ = Describe("Routemonitor", func() {
 		ensureServiceMonitorResourceAbsent = helper.MockHelper{}
 		shouldDeleteBlackBoxExporterResources = helper.MockHelper{}
 		ensureBlackBoxExporterServiceAbsent = helper.MockHelper{}
+		ensureBlackBoxExporterPDBAbsent = helper.MockHelper{}
+		ensureBlackBoxExporterNPAbsent = helper.MockHelper{}
+		ensureBlackBoxExporterSAAbsent = helper.MockHelper{}
 		ensureBlackBoxExporterDeploymentAbsent = helper.MockHelper{}
+		ensureBlackBoxExporterConfigMapAbsent = helper.MockHelper{}
+		ensureBlackBoxExporterConfigMapExists = helper.MockHelper{}
+		ensureBlackBoxExporterSAExists = helper.MockHelper{}
 		ensureBlackBoxExporterDeploymentExists = helper.MockHelper{}
 		ensureBlackBoxExporterServiceExists = helper.MockHelper{}
+		ensureBlackBoxExporterPDBExists = helper.MockHelper{}
+		ensureBlackBoxExporterNPExists = helper.MockHelper{}
 		ensureFinalizerAbsent = helper.MockHelper{}
 		shouldDeleteBlackBoxExporterResourcesResponse = blackbox.KeepBlackBoxExporter
 
@@ -119,18 +139,42 @@ var _ = Describe("Routemonitor", func() {
 			mockDeleter.EXPECT().EnsureBlackBoxExporterServiceAbsent(gomock.Any()).
 				Times(ensureBlackBoxExporterServiceAbsent.CalledTimes).
 				Return(ensureBlackBoxExporterServiceAbsent.ErrorResponse),
+			mockDeleter.EXPECT().EnsureBlackBoxExporterPodDisruptionBudgetAbsent(gomock.Any()).
+				Times(ensureBlackBoxExporterPDBAbsent.CalledTimes).
+				Return(ensureBlackBoxExporterPDBAbsent.ErrorResponse),
+			mockDeleter.EXPECT().EnsureBlackBoxExporterNetworkPolicyAbsent(gomock.Any()).
+				Times(ensureBlackBoxExporterNPAbsent.CalledTimes).
+				Return(ensureBlackBoxExporterNPAbsent.ErrorResponse),
 			mockDeleter.EXPECT().EnsureBlackBoxExporterDeploymentAbsent(gomock.Any()).
 				Times(ensureBlackBoxExporterDeploymentAbsent.CalledTimes).
 				Return(ensureBlackBoxExporterDeploymentAbsent.ErrorResponse),
+			mockDeleter.EXPECT().EnsureBlackBoxExporterServiceAccountAbsent(gomock.Any()).
+				Times(ensureBlackBoxExporterSAAbsent.CalledTimes).
+				Return(ensureBlackBoxExporterSAAbsent.ErrorResponse),
+			mockDeleter.EXPECT().EnsureBlackBoxExporterConfigMapAbsent(gomock.Any()).
+				Times(ensureBlackBoxExporterConfigMapAbsent.CalledTimes).
+				Return(ensureBlackBoxExporterConfigMapAbsent.ErrorResponse),
 		)
 
 		gomock.InOrder(
-			mockAdder.EXPECT().EnsureBlackBoxExporterDeploymentExists(gomock.Any()).
+			mockAdder.EXPECT().EnsureBlackBoxExporterConfigMapExists(gomock.Any(), gomock.Any()).
+				Times(ensureBlackBoxExporterConfigMapExists.CalledTimes).
+				Return(ensureBlackBoxExporterConfigMapExists.ErrorResponse),
+			mockAdder.EXPECT().EnsureBlackBoxExporterServiceAccountExists(gomock.Any()).
+				Times(ensureBlackBoxExporterSAExists.CalledTimes).
+				Return(ensureBlackBoxExporterSAExists.ErrorResponse),
+			mockAdder.EXPECT().EnsureBlackBoxExporterDeploymentExists(gomock.Any(), gomock.Any()).
 				Times(ensureBlackBoxExporterDeploymentExists.CalledTimes).
 				Return(ensureBlackBoxExporterDeploymentExists.ErrorResponse),
 			mockAdder.EXPECT().EnsureBlackBoxExporterServiceExists(gomock.Any()).
 				Times(ensureBlackBoxExporterServiceExists.CalledTimes).
 				Return(ensureBlackBoxExporterServiceExists.ErrorResponse),
+			mockAdder.EXPECT().EnsureBlackBoxExporterPodDisruptionBudgetExists(gomock.Any()).
+				Times(ensureBlackBoxExporterPDBExists.CalledTimes).
+				Return(ensureBlackBoxExporterPDBExists.ErrorResponse),
+			mockAdder.EXPECT().EnsureBlackBoxExporterNetworkPolicyExists(gomock.Any(), gomock.Any()).
+				Times(ensureBlackBoxExporterNPExists.CalledTimes).
+				Return(ensureBlackBoxExporterNPExists.ErrorResponse),
 		)
 
 		mockSupplement.EXPECT().EnsureFinalizerAbsent(gomock.Any(), gomock.Any()).
@@ -198,8 +242,35 @@ var _ = Describe("Routemonitor", func() {
 					Expect(err).To(MatchError(consterror.CustomError))
 				})
 			})
+			When("func EnsureBlackBoxExporterPodDisruptionBudgetAbsent fails unexpectedly", func() {
+				BeforeEach(func() {
+					ensureBlackBoxExporterPDBAbsent = helper.CustomErrorHappensOnce()
+				})
+				It("should bubble up the error", func() {
+					// Act
+					_, err := routeMonitorReconciler.EnsureRouteMonitorAndDependenciesAbsent(ctx, routeMonitor)
+					// Assert
+					Expect(err).To(HaveOccurred())
+					Expect(err).To(MatchError(consterror.CustomError))
+				})
+			})
+			When("func EnsureBlackBoxExporterNetworkPolicyAbsent fails unexpectedly", func() {
+				BeforeEach(func() {
+					ensureBlackBoxExporterPDBAbsent.CalledTimes = 1
+					ensureBlackBoxExporterNPAbsent = helper.CustomErrorHappensOnce()
+				})
+				It("should bubble up the error", func() {
+					// Act
+					_, err := routeMonitorReconciler.EnsureRouteMonitorAndDependenciesAbsent(ctx, routeMonitor)
+					// Assert
+					Expect(err).To(HaveOccurred())
+					Expect(err).To(MatchError(consterror.CustomError))
+				})
+			})
 			When("func EnsureBlackBoxExporterDeploymentAbsent fails unexpectedly", func() {
 				BeforeEach(func() {
+					ensureBlackBoxExporterPDBAbsent.CalledTimes = 1
+					ensureBlackBoxExporterNPAbsent.CalledTimes = 1
 					ensureBlackBoxExporterDeploymentAbsent = helper.CustomErrorHappensOnce()
 				})
 				It("should bubble up the error", func() {
@@ -210,9 +281,44 @@ var _ = Describe("Routemonitor", func() {
 					Expect(err).To(MatchError(consterror.CustomError))
 				})
 			})
+			When("func EnsureBlackBoxExporterServiceAccountAbsent fails unexpectedly", func() {
+				BeforeEach(func() {
+					ensureBlackBoxExporterPDBAbsent.CalledTimes = 1
+					ensureBlackBoxExporterNPAbsent.CalledTimes = 1
+					ensureBlackBoxExporterDeploymentAbsent.CalledTimes = 1
+					ensureBlackBoxExporterSAAbsent = helper.CustomErrorHappensOnce()
+				})
+				It("should bubble up the error", func() {
+					// Act
+					_, err := routeMonitorReconciler.EnsureRouteMonitorAndDependenciesAbsent(ctx, routeMonitor)
+					// Assert
+					Expect(err).To(HaveOccurred())
+					Expect(err).To(MatchError(consterror.CustomError))
+				})
+			})
+			When("func EnsureBlackBoxExporterConfigMapAbsent fails unexpectedly", func() {
+				BeforeEach(func() {
+					ensureBlackBoxExporterPDBAbsent.CalledTimes = 1
+					ensureBlackBoxExporterNPAbsent.CalledTimes = 1
+					ensureBlackBoxExporterDeploymentAbsent.CalledTimes = 1
+					ensureBlackBoxExporterSAAbsent.CalledTimes = 1
+					ensureBlackBoxExporterConfigMapAbsent = helper.CustomErrorHappensOnce()
+				})
+				It("should bubble up the error", func() {
+					// Act
+					_, err := routeMonitorReconciler.EnsureRouteMonitorAndDependenciesAbsent(ctx, routeMonitor)
+					// Assert
+					Expect(err).To(HaveOccurred())
+					Expect(err).To(MatchError(consterror.CustomError))
+				})
+			})
 			When("func EnsureServiceMonitorResourceAbsent fails unexpectedly", func() {
 				BeforeEach(func() {
+					ensureBlackBoxExporterPDBAbsent.CalledTimes = 1
+					ensureBlackBoxExporterNPAbsent.CalledTimes = 1
 					ensureBlackBoxExporterDeploymentAbsent.CalledTimes = 1
+					ensureBlackBoxExporterSAAbsent.CalledTimes = 1
+					ensureBlackBoxExporterConfigMapAbsent.CalledTimes = 1
 					ensureServiceMonitorResourceAbsent = helper.CustomErrorHappensOnce()
 				})
 				It("should bubble up the error", func() {
@@ -226,7 +332,11 @@ var _ = Describe("Routemonitor", func() {
 			When("func EnsureFinalizerAbsent fails unexpectedly", func() {
 				BeforeEach(func() {
 					ensureBlackBoxExporterServiceAbsent.CalledTimes = 1
+					ensureBlackBoxExporterPDBAbsent.CalledTimes = 1
+					ensureBlackBoxExporterNPAbsent.CalledTimes = 1
 					ensureBlackBoxExporterDeploymentAbsent.CalledTimes = 1
+					ensureBlackBoxExporterSAAbsent.CalledTimes = 1
+					ensureBlackBoxExporterConfigMapAbsent.CalledTimes = 1
 					ensureServiceMonitorResourceAbsent.CalledTimes = 1
 					ensureFinalizerAbsent = helper.CustomErrorHappensOnce()
 				})
@@ -240,7 +350,11 @@ var _ = Describe("Routemonitor", func() {
 			})
 			When("all deletions happened successfully", func() {
 				BeforeEach(func() {
+					ensureBlackBoxExporterPDBAbsent.CalledTimes = 1
+					ensureBlackBoxExporterNPAbsent.CalledTimes = 1
 					ensureBlackBoxExporterDeploymentAbsent.CalledTimes = 1
+					ensureBlackBoxExporterSAAbsent.CalledTimes = 1
+					ensureBlackBoxExporterConfigMapAbsent.CalledTimes = 1
 					ensureServiceMonitorResourceAbsent.CalledTimes = 1
 					ensureFinalizerAbsent.CalledTimes = 1
 				})
@@ -354,8 +468,39 @@ var _ = Describe("Routemonitor", func() {
 	Describe("EnsureBlackBoxExporterResourcesExists", func() {
 		BeforeEach(func() {
 			// Arrange
+			ensureBlackBoxExporterConfigMapExists.CalledTimes = 1
+			ensureBlackBoxExporterSAExists.CalledTimes = 1
 			ensureBlackBoxExporterDeploymentExists.CalledTimes = 1
 		})
+		When("func EnsureBlackBoxExporterConfigMapExists fails unexpectedly", func() {
+			BeforeEach(func() {
+				// Arrange
+				ensureBlackBoxExporterConfigMapExists.ErrorResponse = consterror.CustomError
+				ensureBlackBoxExporterSAExists.CalledTimes = 0
+				ensureBlackBoxExporterDeploymentExists.CalledTimes = 0
+			})
+			It("should bubble up the error", func() {
+				// Act
+				err := routeMonitorReconciler.EnsureBlackBoxExporterResourcesExists(ctx, v1alpha1.RouteMonitorOperatorConfigSpec{})
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+		When("func EnsureBlackBoxExporterServiceAccountExists fails unexpectedly", func() {
+			BeforeEach(func() {
+				// Arrange
+				ensureBlackBoxExporterSAExists.ErrorResponse = consterror.CustomError
+				ensureBlackBoxExporterDeploymentExists.CalledTimes = 0
+			})
+			It("should bubble up the error", func() {
+				// Act
+				err := routeMonitorReconciler.EnsureBlackBoxExporterResourcesExists(ctx, v1alpha1.RouteMonitorOperatorConfigSpec{})
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
 		When("func EnsureBlackBoxExporterDeploymentExists fails unexpectedly", func() {
 			BeforeEach(func() {
 				// Arrange
@@ -363,7 +508,7 @@ var _ = Describe("Routemonitor", func() {
 			})
 			It("should bubble up the error", func() {
 				// Act
-				err := routeMonitorReconciler.EnsureBlackBoxExporterResourcesExists(ctx)
+				err := routeMonitorReconciler.EnsureBlackBoxExporterResourcesExists(ctx, v1alpha1.RouteMonitorOperatorConfigSpec{})
 				// Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.CustomError))
@@ -376,24 +521,195 @@ var _ = Describe("Routemonitor", func() {
 			})
 			It("should bubble up the error", func() {
 				// Act
-				err := routeMonitorReconciler.EnsureBlackBoxExporterResourcesExists(ctx)
+				err := routeMonitorReconciler.EnsureBlackBoxExporterResourcesExists(ctx, v1alpha1.RouteMonitorOperatorConfigSpec{})
 				// Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.CustomError))
 			})
 		})
-		When("func EnsureBlackBoxExporterServiceExists fails unexpectedly", func() {
+		When("func EnsureBlackBoxExporterPodDisruptionBudgetExists fails unexpectedly", func() {
+			BeforeEach(func() {
+				// Arrange
+				ensureBlackBoxExporterServiceExists.CalledTimes = 1
+				ensureBlackBoxExporterPDBExists = helper.CustomErrorHappensOnce()
+			})
+			It("should bubble up the error", func() {
+				// Act
+				err := routeMonitorReconciler.EnsureBlackBoxExporterResourcesExists(ctx, v1alpha1.RouteMonitorOperatorConfigSpec{})
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+		When("func EnsureBlackBoxExporterNetworkPolicyExists fails unexpectedly", func() {
 			BeforeEach(func() {
 				// Arrange
 				ensureBlackBoxExporterServiceExists.CalledTimes = 1
+				ensureBlackBoxExporterPDBExists.CalledTimes = 1
+				ensureBlackBoxExporterNPExists = helper.CustomErrorHappensOnce()
+			})
+			It("should bubble up the error", func() {
+				// Act
+				err := routeMonitorReconciler.EnsureBlackBoxExporterResourcesExists(ctx, v1alpha1.RouteMonitorOperatorConfigSpec{})
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+		When("all resources were ensured", func() {
+			BeforeEach(func() {
+				// Arrange
+				ensureBlackBoxExporterServiceExists.CalledTimes = 1
+				ensureBlackBoxExporterPDBExists.CalledTimes = 1
+				ensureBlackBoxExporterNPExists.CalledTimes = 1
 			})
 			It("should succeed with no error", func() {
 				// Act
-				err := routeMonitorReconciler.EnsureBlackBoxExporterResourcesExists(ctx)
+				err := routeMonitorReconciler.EnsureBlackBoxExporterResourcesExists(ctx, v1alpha1.RouteMonitorOperatorConfigSpec{})
 				// Assert
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
 	})
-
+	Describe("EnsureAlertingReady", func() {
+		var ensurePrometheusRuleResourceExists helper.MockHelper
+		BeforeEach(func() {
+			ensurePrometheusRuleResourceExists = helper.MockHelper{CalledTimes: 1}
+		})
+		JustBeforeEach(func() {
+			mockAdder.EXPECT().EnsurePrometheusRuleResourceExists(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(ensurePrometheusRuleResourceExists.CalledTimes).
+				Return(ensurePrometheusRuleResourceExists.ErrorResponse)
+		})
+		When("func EnsurePrometheusRuleResourceExists fails on a foreign PrometheusRule", func() {
+			BeforeEach(func() {
+				// Arrange
+				ensurePrometheusRuleResourceExists.ErrorResponse = fmt.Errorf("%w: PrometheusRule ns/name", customerrors.ForeignResource)
+			})
+			It("should fail AlertingReady and leave ServiceMonitorReady alone", func() {
+				// Arrange
+				routeMonitor.Spec.Alerting = &v1alpha1.RouteMonitorAlerting{}
+				routeMonitor.SetCondition(v1alpha1.ConditionServiceMonitorReady, metav1.ConditionTrue, v1alpha1.ReasonResourcesExist, "The ServiceMonitor probing the Route exists")
+				// Act
+				err := routeMonitorReconciler.EnsureAlertingReady(ctx, &routeMonitor, v1alpha1.RouteMonitorOperatorConfigSpec{})
+				// Assert
+				Expect(err).To(MatchError(ensurePrometheusRuleResourceExists.ErrorResponse))
+				alertingReady := v1alpha1.FindCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionAlertingReady)
+				Expect(alertingReady).NotTo(BeNil())
+				Expect(alertingReady.Status).To(Equal(metav1.ConditionFalse))
+				Expect(alertingReady.Reason).To(Equal(v1alpha1.ReasonResourceForeign))
+				serviceMonitorReady := v1alpha1.FindCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionServiceMonitorReady)
+				Expect(serviceMonitorReady.Status).To(Equal(metav1.ConditionTrue))
+			})
+		})
+		When("the RouteMonitor has alerting", func() {
+			It("should mark AlertingReady as ready", func() {
+				// Arrange
+				routeMonitor.Spec.Alerting = &v1alpha1.RouteMonitorAlerting{}
+				// Act
+				err := routeMonitorReconciler.EnsureAlertingReady(ctx, &routeMonitor, v1alpha1.RouteMonitorOperatorConfigSpec{})
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				alertingReady := v1alpha1.FindCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionAlertingReady)
+				Expect(alertingReady.Status).To(Equal(metav1.ConditionTrue))
+				Expect(alertingReady.Reason).To(Equal(v1alpha1.ReasonResourcesExist))
+			})
+		})
+		When("the RouteMonitor has no alerting", func() {
+			It("should mark AlertingReady as ready with alerting disabled", func() {
+				// Act
+				err := routeMonitorReconciler.EnsureAlertingReady(ctx, &routeMonitor, v1alpha1.RouteMonitorOperatorConfigSpec{})
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				alertingReady := v1alpha1.FindCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionAlertingReady)
+				Expect(alertingReady.Status).To(Equal(metav1.ConditionTrue))
+				Expect(alertingReady.Reason).To(Equal(v1alpha1.ReasonAlertingDisabled))
+			})
+		})
+	})
+	Describe("EnsureStatusUpdated", func() {
+		var fetchedStatus v1alpha1.RouteMonitorStatus
+		JustBeforeEach(func() {
+			// Arrange
+			routeMonitor.Generation = 2
+			routeMonitor.SetCondition(v1alpha1.ConditionRouteFound, metav1.ConditionFalse, v1alpha1.ReasonRouteNotFound, "route not found")
+		})
+		When("the conditions changed", func() {
+			JustBeforeEach(func() {
+				// Arrange
+				fetchedStatus = v1alpha1.RouteMonitorStatus{}
+				mockClient.EXPECT().Status().Return(mockStatusWriter).Times(1)
+				mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, obj *v1alpha1.RouteMonitor, _ ...client.UpdateOption) error {
+						// Assert
+						Expect(obj.Status.ObservedGeneration).To(Equal(int64(2)))
+						ready := v1alpha1.FindCondition(obj.Status.Conditions, v1alpha1.ConditionReady)
+						Expect(ready).NotTo(BeNil())
+						Expect(ready.Reason).To(Equal(v1alpha1.ReasonWaitingForCondition))
+						return nil
+					})
+			})
+			It("should write the status with the Ready condition", func() {
+				// Act
+				err := routeMonitorReconciler.EnsureStatusUpdated(ctx, routeMonitor, fetchedStatus)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		When("func Update fails unexpectedly", func() {
+			JustBeforeEach(func() {
+				// Arrange
+				fetchedStatus = v1alpha1.RouteMonitorStatus{}
+				mockClient.EXPECT().Status().Return(mockStatusWriter).Times(1)
+				mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(consterror.CustomError)
+			})
+			It("should bubble up the error", func() {
+				// Act
+				err := routeMonitorReconciler.EnsureStatusUpdated(ctx, routeMonitor, fetchedStatus)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+		When("the conditions did not change", func() {
+			JustBeforeEach(func() {
+				// Arrange
+				routeMonitor.SetReadyCondition()
+				routeMonitor.Status.ObservedGeneration = routeMonitor.Generation
+				fetchedStatus = *routeMonitor.Status.DeepCopy()
+			})
+			It("should skip the write", func() {
+				// Act
+				err := routeMonitorReconciler.EnsureStatusUpdated(ctx, routeMonitor, fetchedStatus)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+	Describe("IndexRouteMonitorByRoute", func() {
+		When("the RouteMonitor references a Route", func() {
+			It("should index it by the namespace/name of the Route", func() {
+				// Arrange
+				indexed := v1alpha1.RouteMonitor{
+					ObjectMeta: metav1.ObjectMeta{Name: "routemonitor", Namespace: "namespace"},
+					Spec:       v1alpha1.RouteMonitorSpec{Route: v1alpha1.RouteMonitorRouteSpec{Name: "route"}},
+				}
+				// Act
+				res := routemonitor.IndexRouteMonitorByRoute(&indexed)
+				// Assert
+				Expect(res).To(Equal([]string{"namespace/route"}))
+			})
+		})
+		When("the RouteMonitor probes a url", func() {
+			It("should not index it", func() {
+				// Arrange
+				indexed := v1alpha1.RouteMonitor{Spec: v1alpha1.RouteMonitorSpec{URL: "https://example.com"}}
+				// Act
+				res := routemonitor.IndexRouteMonitorByRoute(&indexed)
+				// Assert
+				Expect(res).To(BeEmpty())
+			})
+		})
+	})
 })
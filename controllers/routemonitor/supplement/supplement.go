@@ -2,17 +2,21 @@ package supplement
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"net/url"
+	"path"
 	"reflect"
+	"sort"
 
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
 	"github.com/openshift/route-monitor-operator/controllers/routemonitor"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -40,6 +44,21 @@ func New(r routemonitor.RouteMonitorReconciler) *RouteMonitorSupplement {
 	}
 }
 
+// GetOperatorConfig returns the spec of the RouteMonitorOperatorConfig, or an empty spec when there is none.
+// It is read on every reconcile, so changes of the probe defaults apply to the RouteMonitors that leave the settings empty
+func (r *RouteMonitorSupplement) GetOperatorConfig(ctx context.Context) (v1alpha1.RouteMonitorOperatorConfigSpec, error) {
+	config := v1alpha1.RouteMonitorOperatorConfig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: v1alpha1.OperatorConfigName}, &config); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return v1alpha1.RouteMonitorOperatorConfigSpec{}, err
+		}
+	}
+	if err := config.Spec.ProbeDefaults.Validate(); err != nil {
+		return v1alpha1.RouteMonitorOperatorConfigSpec{}, err
+	}
+	return config.Spec, nil
+}
+
 // GetRouteMonitor return the RouteMonitor that is tested
 func (r *RouteMonitorSupplement) GetRouteMonitor(ctx context.Context, req ctrl.Request) (v1alpha1.RouteMonitor, utilreconcile.Result, error) {
 	routeMonitor := v1alpha1.RouteMonitor{}
@@ -64,59 +83,164 @@ func (r *RouteMonitorSupplement) GetRouteMonitor(ctx context.Context, req ctrl.R
 	return routeMonitor, utilreconcile.ContinueOperation(), nil
 }
 
-// GetRoute returns the Route from the RouteMonitor spec
-func (r *RouteMonitorSupplement) GetRoute(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) (routev1.Route, error) {
-	res := routev1.Route{}
-	nsName := types.NamespacedName{
-		Name:      routeMonitor.Spec.Route.Name,
-		Namespace: routeMonitor.Spec.Route.Namespace,
+// GetRoutes returns the Route from the RouteMonitor spec, or every Route selected by its RouteSelector sorted by name.
+// A RouteMonitor probing the URL of its spec has no Routes
+func (r *RouteMonitorSupplement) GetRoutes(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) ([]routev1.Route, error) {
+	if err := routeMonitor.ValidateRoute(); err != nil {
+		return nil, err
 	}
-	if nsName.Name == "" || nsName.Namespace == "" {
-		err := errors.New("Invalid CR: Cannot retrieve route if one of the fields is empty")
-		return res, err
+	// the url of the spec is probed without a Route
+	if routeMonitor.Spec.URL != "" {
+		return nil, nil
 	}
 
-	err := r.Get(ctx, nsName, &res)
-	if err != nil {
-		return res, err
+	if routeMonitor.Spec.RouteSelector == nil {
+		res := routev1.Route{}
+		nsName := types.NamespacedName{
+			Name:      routeMonitor.Spec.Route.Name,
+			Namespace: routeMonitor.GetRouteNamespace(),
+		}
+		if err := r.Get(ctx, nsName, &res); err != nil {
+			return nil, err
+		}
+		return []routev1.Route{res}, nil
 	}
-	return res, nil
-}
 
-// EnsureRouteURLExists verifies that the .spec.RouteURL has the Route URL inside
-func (r *RouteMonitorSupplement) EnsureRouteURLExists(ctx context.Context, route routev1.Route, routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
-	amountOfIngress := len(route.Status.Ingress)
-	if amountOfIngress == 0 {
-		err := errors.New("No Ingress: cannot extract route url from the Route resource")
-		return utilreconcile.RequeueReconcileWith(err)
+	// the selector was parsed by ValidateRoute already
+	selector, _ := metav1.LabelSelectorAsSelector(routeMonitor.Spec.RouteSelector)
+	routeList := routev1.RouteList{}
+	err := r.List(ctx, &routeList, client.InNamespace(routeMonitor.Namespace), client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return nil, err
 	}
-	extractedRouteURL := route.Status.Ingress[0].Host
-	if amountOfIngress > 1 {
-		r.Log.V(1).Info(fmt.Sprintf("Too many Ingress: assuming first ingress is the correct, chosen ingress '%s'", extractedRouteURL))
+	if len(routeList.Items) == 0 {
+		return nil, customerrors.NoRoute
 	}
+	sort.Slice(routeList.Items, func(i, j int) bool {
+		return routeList.Items[i].Name < routeList.Items[j].Name
+	})
+	return routeList.Items, nil
+}
 
-	if extractedRouteURL == "" {
-		return utilreconcile.RequeueReconcileWith(customerrors.NoHost)
+// EnsureRouteURLExists verifies that the .status.RouteURLs hold the urls of every admitted ingress of the Routes,
+// or the URL of the spec
+func (r *RouteMonitorSupplement) EnsureRouteURLExists(ctx context.Context, routes []routev1.Route, routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
+	var routeNames []string
+	var extractedRouteURLs []v1alpha1.RouteMonitorURL
+	var err error
+	if routeMonitor.Spec.URL != "" {
+		extractedRouteURLs, err = extractSpecURL(routeMonitor)
+	} else {
+		routeNames, extractedRouteURLs, err = r.extractRouteURLs(routes, routeMonitor)
+	}
+	if err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
 	}
 
-	currentRouteURL := routeMonitor.Status.RouteURL
-	if currentRouteURL == extractedRouteURL {
-		r.Log.V(3).Info("Same RouteURL: currentRouteURL and extractedRouteURL are equal, update not required")
+	currentRouteURLs := routeMonitor.Status.RouteURLs
+	if reflect.DeepEqual(currentRouteURLs, extractedRouteURLs) && reflect.DeepEqual(routeMonitor.Status.Routes, routeNames) {
+		r.Log.V(3).Info("Same RouteURLs: currentRouteURLs and extractedRouteURLs are equal, update not required")
 		return utilreconcile.ContinueReconcile()
 	}
 
-	if currentRouteURL != "" && extractedRouteURL != currentRouteURL {
-		r.Log.V(3).Info("RouteURL mismatch: currentRouteURL and extractedRouteURL are not equal, taking extractedRouteURL as source of truth")
+	if len(currentRouteURLs) != 0 {
+		r.Log.V(3).Info("RouteURLs mismatch: currentRouteURLs and extractedRouteURLs are not equal, taking extractedRouteURLs as source of truth")
 	}
 
-	routeMonitor.Status.RouteURL = extractedRouteURL
-	err := r.Status().Update(ctx, &routeMonitor)
+	routeMonitor.Status.Routes = routeNames
+	routeMonitor.Status.RouteURLs = extractedRouteURLs
+	err = r.Status().Update(ctx, &routeMonitor)
 	if err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
 	return utilreconcile.StopReconcile()
 }
 
+// extractRouteURLs returns the names of the Routes and the urls of their admitted ingresses
+func (r *RouteMonitorSupplement) extractRouteURLs(routes []routev1.Route, routeMonitor v1alpha1.RouteMonitor) ([]string, []v1alpha1.RouteMonitorURL, error) {
+	routeNames := []string{}
+	extractedRouteURLs := []v1alpha1.RouteMonitorURL{}
+	hasAdmittedIngress := false
+	for _, route := range routes {
+		routeNames = append(routeNames, route.Name)
+		for _, ingress := range route.Status.Ingress {
+			if !isAdmitted(ingress) {
+				continue
+			}
+			hasAdmittedIngress = true
+			if ingress.Host == "" {
+				r.Log.V(1).Info(fmt.Sprintf("No Host: skipping ingress of router '%s' for route '%s'", ingress.RouterName, route.Name))
+				continue
+			}
+			extractedRouteURL := url.URL{
+				Scheme: templateForScheme(route),
+				Host:   ingress.Host,
+				Path:   templateForPath(route, routeMonitor),
+			}
+			extractedRouteURLs = append(extractedRouteURLs, v1alpha1.RouteMonitorURL{
+				RouteName:  route.Name,
+				RouterName: ingress.RouterName,
+				URL:        extractedRouteURL.String(),
+				Scheme:     extractedRouteURL.Scheme,
+				Host:       extractedRouteURL.Host,
+				Path:       extractedRouteURL.Path,
+			})
+		}
+	}
+
+	if !hasAdmittedIngress {
+		return nil, nil, customerrors.NoIngress
+	}
+	if len(extractedRouteURLs) == 0 {
+		return nil, nil, customerrors.NoHost
+	}
+	return routeNames, extractedRouteURLs, nil
+}
+
+// extractSpecURL returns the URL of the spec, joined with the HealthPath like the path of a Route
+func extractSpecURL(routeMonitor v1alpha1.RouteMonitor) ([]v1alpha1.RouteMonitorURL, error) {
+	if err := routeMonitor.ValidateURL(); err != nil {
+		return nil, err
+	}
+	// the url was parsed by ValidateURL already
+	specURL, _ := url.Parse(routeMonitor.Spec.URL)
+	if routeMonitor.Spec.HealthPath != "" {
+		specURL.Path = path.Join("/", specURL.Path, routeMonitor.Spec.HealthPath)
+	}
+	return []v1alpha1.RouteMonitorURL{{
+		URL:    specURL.String(),
+		Scheme: specURL.Scheme,
+		Host:   specURL.Host,
+		Path:   specURL.Path,
+	}}, nil
+}
+
+// isAdmitted verifies that the router of the ingress accepted the Route
+func isAdmitted(ingress routev1.RouteIngress) bool {
+	for _, condition := range ingress.Conditions {
+		if condition.Type == routev1.RouteAdmitted {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// templateForScheme returns https for Routes that are secured by TLS, otherwise http
+func templateForScheme(route routev1.Route) string {
+	if route.Spec.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// templateForPath joins the path of the Route with the HealthPath of the RouteMonitor
+func templateForPath(route routev1.Route, routeMonitor v1alpha1.RouteMonitor) string {
+	if route.Spec.Path == "" && routeMonitor.Spec.HealthPath == "" {
+		return ""
+	}
+	return path.Join("/", route.Spec.Path, routeMonitor.Spec.HealthPath)
+}
+
 func (r *RouteMonitorSupplement) EnsureFinalizerAbsent(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
 	if routeMonitor.HasFinalizer() {
 		// if finalizer is still here and ServiceMonitor is deleted, then remove the finalizer
@@ -9,6 +9,7 @@ import (
 	// tested package
 	"github.com/openshift/route-monitor-operator/controllers/routemonitor"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -40,6 +41,7 @@ var _ = Describe("Routemonitor", func() {
 		routeMonitorName              string
 		routeMonitorNamespace         string
 		routeMonitorRouteSpec         v1alpha1.RouteMonitorRouteSpec
+		routeMonitorRouteSelector     *metav1.LabelSelector
 		routeMonitorFinalizers        []string
 		routeMonitorDeletionTimestamp *metav1.Time
 		routeMonitorStatus            v1alpha1.RouteMonitorStatus
@@ -78,6 +80,7 @@ var _ = Describe("Routemonitor", func() {
 		routeMonitorName = "fake-name"
 		routeMonitorNamespace = "fake-namespace"
 		routeMonitorRouteSpec = v1alpha1.RouteMonitorRouteSpec{}
+		routeMonitorRouteSelector = nil
 		routeMonitorSupplementClient = fake.NewFakeClientWithScheme(scheme)
 		routeMonitorFinalizers = routemonitorconst.FinalizerList
 		routeMonitorDeletionTimestamp = nil
@@ -102,7 +105,8 @@ var _ = Describe("Routemonitor", func() {
 				DeletionTimestamp: routeMonitorDeletionTimestamp,
 			},
 			Spec: v1alpha1.RouteMonitorSpec{
-				Route: routeMonitorRouteSpec,
+				Route:         routeMonitorRouteSpec,
+				RouteSelector: routeMonitorRouteSelector,
 			},
 			Status: routeMonitorStatus,
 		}
@@ -145,6 +149,65 @@ var _ = Describe("Routemonitor", func() {
 	AfterEach(func() {
 		mockCtrl.Finish()
 	})
+	Describe("GetOperatorConfig", func() {
+		When("func Get fails unexpectedly", func() {
+			// Arrange
+			BeforeEach(func() {
+				get = helper.CustomErrorHappensOnce()
+				routeMonitorSupplementClient = mockClient
+			})
+			It("should return the error", func() {
+				// Act
+				_, err := routeMonitorSupplement.GetOperatorConfig(ctx)
+				// Assert
+				Expect(err).To(MatchError(consterror.CustomError))
+			})
+		})
+		When("there is no RouteMonitorOperatorConfig", func() {
+			It("should return an empty config, so the built-in defaults apply", func() {
+				// Act
+				config, err := routeMonitorSupplement.GetOperatorConfig(ctx)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config).To(BeZero())
+			})
+		})
+		When("the RouteMonitorOperatorConfig sets probe defaults", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorSupplementClient = fake.NewFakeClientWithScheme(scheme, &v1alpha1.RouteMonitorOperatorConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.OperatorConfigName},
+					Spec: v1alpha1.RouteMonitorOperatorConfigSpec{
+						ProbeDefaults: v1alpha1.ProbeDefaults{Interval: "1m", ScrapeTimeout: "30s"},
+					},
+				})
+			})
+			It("should return the config with its probe defaults", func() {
+				// Act
+				config, err := routeMonitorSupplement.GetOperatorConfig(ctx)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.ProbeDefaults).To(Equal(v1alpha1.ProbeDefaults{Interval: "1m", ScrapeTimeout: "30s"}))
+			})
+		})
+		When("the probe defaults of the RouteMonitorOperatorConfig are invalid", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorSupplementClient = fake.NewFakeClientWithScheme(scheme, &v1alpha1.RouteMonitorOperatorConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.OperatorConfigName},
+					Spec: v1alpha1.RouteMonitorOperatorConfigSpec{
+						ProbeDefaults: v1alpha1.ProbeDefaults{Interval: "10s"},
+					},
+				})
+			})
+			It("should return an error", func() {
+				// Act
+				_, err := routeMonitorSupplement.GetOperatorConfig(ctx)
+				// Assert
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 	Describe("GetRouteMonitor", func() {
 		When("func Get fails unexpectedly", func() {
 			// Arrange
@@ -191,7 +254,7 @@ var _ = Describe("Routemonitor", func() {
 		})
 	})
 
-	Describe("GetRoute", func() {
+	Describe("GetRoutes", func() {
 		BeforeEach(func() {
 			routeMonitorRouteSpec = v1alpha1.RouteMonitorRouteSpec{
 				Name:      routeMonitorName,
@@ -211,11 +274,11 @@ var _ = Describe("Routemonitor", func() {
 			})
 			It("should return a Not Found error", func() {
 				// Act
-				res, err := routeMonitorSupplement.GetRoute(ctx, routeMonitor)
+				res, err := routeMonitorSupplement.GetRoutes(ctx, routeMonitor)
 				// Assert
 				Expect(err).To(HaveOccurred())
 				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
-				Expect(res).To(BeZero())
+				Expect(res).To(BeEmpty())
 
 			})
 		})
@@ -233,10 +296,77 @@ var _ = Describe("Routemonitor", func() {
 			})
 			It("should return the route", func() {
 				// Act
-				resRoute, err := routeMonitorSupplement.GetRoute(ctx, routeMonitor)
+				resRoute, err := routeMonitorSupplement.GetRoutes(ctx, routeMonitor)
 				// Assert
 				Expect(err).NotTo(HaveOccurred())
-				Expect(resRoute).ShouldNot(BeNil())
+				Expect(resRoute).To(HaveLen(1))
+			})
+		})
+		When("the RouteMonitor selects Routes", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorRouteSpec = v1alpha1.RouteMonitorRouteSpec{}
+				routeMonitorRouteSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "public"}}
+				routeMonitorSupplementClient = fake.NewFakeClientWithScheme(scheme,
+					&routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: routeMonitorNamespace, Labels: map[string]string{"tier": "public"}}},
+					&routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: routeMonitorNamespace, Labels: map[string]string{"tier": "public"}}},
+					&routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: "private", Namespace: routeMonitorNamespace, Labels: map[string]string{"tier": "private"}}},
+					&routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "other-namespace", Labels: map[string]string{"tier": "public"}}},
+				)
+			})
+			It("should return the matching Routes of its namespace sorted by name", func() {
+				// Act
+				resRoutes, err := routeMonitorSupplement.GetRoutes(ctx, routeMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resRoutes).To(HaveLen(2))
+				Expect(resRoutes[0].Name).To(Equal("first"))
+				Expect(resRoutes[1].Name).To(Equal("second"))
+			})
+		})
+		When("the RouteSelector matches no Route", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorRouteSpec = v1alpha1.RouteMonitorRouteSpec{}
+				routeMonitorRouteSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "public"}}
+				routeMonitorSupplementClient = fake.NewFakeClientWithScheme(scheme)
+			})
+			It("should return No Route error", func() {
+				// Act
+				resRoutes, err := routeMonitorSupplement.GetRoutes(ctx, routeMonitor)
+				// Assert
+				Expect(err).To(MatchError(customerrors.NoRoute))
+				Expect(resRoutes).To(BeEmpty())
+			})
+		})
+		When("the RouteMonitor has both a Route and a RouteSelector", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorRouteSelector = &metav1.LabelSelector{}
+			})
+			It("should return an Invalid CR error", func() {
+				// Act
+				_, err := routeMonitorSupplement.GetRoutes(ctx, routeMonitor)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Invalid CR:"))
+			})
+		})
+
+		When("the RouteMonitor probes a url", func() {
+			// Arrange
+			BeforeEach(func() {
+				routeMonitorRouteSpec = v1alpha1.RouteMonitorRouteSpec{}
+			})
+			JustBeforeEach(func() {
+				routeMonitor.Spec.URL = "https://example.com/status"
+			})
+			It("should return no Route", func() {
+				// Act
+				resRoutes, err := routeMonitorSupplement.GetRoutes(ctx, routeMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resRoutes).To(BeEmpty())
 			})
 		})
 
@@ -251,14 +381,20 @@ var _ = Describe("Routemonitor", func() {
 				// Arrange
 				BeforeEach(func() {
 					routeMonitorRouteSpec.Namespace = ""
+					routeMonitorSupplementClient = fake.NewFakeClientWithScheme(scheme, &routev1.Route{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      routeMonitorName,
+							Namespace: routeMonitorNamespace,
+						},
+					})
 				})
-				It("should return a custom error", func() {
+				It("should return the route of the RouteMonitor namespace", func() {
 					// Act
-					resRoute, err := routeMonitorSupplement.GetRoute(ctx, routeMonitor)
+					resRoute, err := routeMonitorSupplement.GetRoutes(ctx, routeMonitor)
 					// Assert
-					Expect(err).To(HaveOccurred())
-					Expect(err.Error()).To(HavePrefix("Invalid CR:"))
-					Expect(resRoute).To(BeZero())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resRoute).To(HaveLen(1))
+					Expect(resRoute[0].Namespace).To(Equal(routeMonitorNamespace))
 				})
 			})
 			When("the RouteMonitor doesnt have Spec.Route.Name", func() {
@@ -268,19 +404,30 @@ var _ = Describe("Routemonitor", func() {
 				})
 				It("should return a custom error", func() {
 					// Act
-					resRoute, err := routeMonitorSupplement.GetRoute(ctx, routeMonitor)
+					resRoute, err := routeMonitorSupplement.GetRoutes(ctx, routeMonitor)
 					// Assert
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(HavePrefix("Invalid CR:"))
-					Expect(resRoute).To(BeZero())
+					Expect(resRoute).To(BeEmpty())
 				})
 			})
 		})
 	})
 	Describe("EnsureRouteURLExists", func() {
-		var ingresses []string
+		const routeName = "fake-route"
+		var (
+			ingresses []string
+			routeSpec routev1.RouteSpec
+		)
+		BeforeEach(func() {
+			routeSpec = routev1.RouteSpec{}
+		})
 		JustBeforeEach(func() {
 			route = routev1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: routeName,
+				},
+				Spec: routeSpec,
 				Status: routev1.RouteStatus{
 					Ingress: ConvertToIngressHosts(ingresses),
 				},
@@ -290,7 +437,21 @@ var _ = Describe("Routemonitor", func() {
 			// Arrange
 			It("should return No Ingress error", func() {
 				// Act
-				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, route, routeMonitor)
+				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, []routev1.Route{route}, routeMonitor)
+				// Assert
+				Expect(res).To(BeZero())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("No Ingress:"))
+			})
+		})
+		When("the Route has no admitted Ingress", func() {
+			// Arrange
+			JustBeforeEach(func() {
+				route.Status.Ingress = []routev1.RouteIngress{{Host: routeMonitorRouteURLDefault}}
+			})
+			It("should return No Ingress error", func() {
+				// Act
+				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, []routev1.Route{route}, routeMonitor)
 				// Assert
 				Expect(res).To(BeZero())
 				Expect(err).To(HaveOccurred())
@@ -304,7 +465,7 @@ var _ = Describe("Routemonitor", func() {
 			})
 			It("should return No Host error", func() {
 				// Act
-				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, route, routeMonitor)
+				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, []routev1.Route{route}, routeMonitor)
 				// Assert
 				Expect(res).To(BeZero())
 				Expect(err).To(HaveOccurred())
@@ -327,7 +488,7 @@ var _ = Describe("Routemonitor", func() {
 				routeMonitorSupplementClient = mockClient
 			})
 			JustBeforeEach(func() {
-				expectedRouteMonitor.Status.RouteURL = firstRouteURL
+				expectedRouteMonitor.Status.RouteURLs = []v1alpha1.RouteMonitorURL{{RouteName: routeName, URL: firstRouteURL}}
 				mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(consterror.CustomError)
@@ -335,13 +496,13 @@ var _ = Describe("Routemonitor", func() {
 			})
 			It("should bubble up the error", func() {
 				// Act
-				_, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, route, routeMonitor)
+				_, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, []routev1.Route{route}, routeMonitor)
 				// Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.CustomError))
 			})
 		})
-		When("the Route has too many Ingress", func() {
+		When("the Route has multiple Ingress", func() {
 			// Arrange
 			var (
 				firstRouteURL = "freddy"
@@ -356,13 +517,29 @@ var _ = Describe("Routemonitor", func() {
 				routeMonitorSupplementClient = mockClient
 			})
 			JustBeforeEach(func() {
-				expectedRouteMonitor.Status.RouteURL = firstRouteURL
+				expectedRouteMonitor.Status = v1alpha1.RouteMonitorStatus{
+					Routes: []string{routeName},
+					RouteURLs: []v1alpha1.RouteMonitorURL{
+						{
+							RouteName: routeName,
+							URL:       "http://" + firstRouteURL,
+							Scheme:    "http",
+							Host:      firstRouteURL,
+						},
+						{
+							RouteName: routeName,
+							URL:       "http://eddie",
+							Scheme:    "http",
+							Host:      "eddie",
+						},
+					},
+				}
 				mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Eq(&expectedRouteMonitor)).Times(1).Return(nil)
 
 			})
-			It("should update the first ingress", func() {
+			It("should update with every ingress", func() {
 				// Act
-				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, route, routeMonitor)
+				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, []routev1.Route{route}, routeMonitor)
 				// Assert
 				Expect(err).NotTo(HaveOccurred())
 				Expect(res).NotTo(BeNil())
@@ -383,14 +560,24 @@ var _ = Describe("Routemonitor", func() {
 				routeMonitorSupplementClient = mockClient
 			})
 			JustBeforeEach(func() {
-				routeMonitor.Status.RouteURL = firstRouteURL + "but-different"
-				expectedRouteMonitor.Status.RouteURL = firstRouteURL
+				routeMonitor.Status.RouteURLs = []v1alpha1.RouteMonitorURL{{URL: firstRouteURL + "but-different"}}
+				expectedRouteMonitor.Status = v1alpha1.RouteMonitorStatus{
+					Routes: []string{routeName},
+					RouteURLs: []v1alpha1.RouteMonitorURL{
+						{
+							RouteName: routeName,
+							URL:       "http://" + firstRouteURL,
+							Scheme:    "http",
+							Host:      firstRouteURL,
+						},
+					},
+				}
 				mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Eq(&expectedRouteMonitor)).Times(1).Return(nil)
 
 			})
 			It("should update with the Route information", func() {
 				// Act
-				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, route, routeMonitor)
+				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, []routev1.Route{route}, routeMonitor)
 				// Assert
 				Expect(err).NotTo(HaveOccurred())
 				Expect(res).NotTo(BeNil())
@@ -398,24 +585,181 @@ var _ = Describe("Routemonitor", func() {
 			})
 		})
 
-		When("the Route has the same RouteURL as the extracted one", func() {
+		When("multiple Routes are selected", func() {
 			// Arrange
+			var secondRoute routev1.Route
 			BeforeEach(func() {
 				ingresses = []string{
 					routeMonitorRouteURLDefault,
 				}
+				secondRoute = routev1.Route{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "second-route",
+					},
+					Status: routev1.RouteStatus{
+						Ingress: ConvertToIngressHosts([]string{"second-host"}),
+					},
+				}
 
-				routeMonitorStatus = v1alpha1.RouteMonitorStatus{
-					RouteURL: routeMonitorRouteURLDefault,
+				mockClient.EXPECT().Status().Return(mockStatusWriter).Times(1)
+				routeMonitorSupplementClient = mockClient
+			})
+			JustBeforeEach(func() {
+				expectedRouteMonitor.Status = v1alpha1.RouteMonitorStatus{
+					Routes: []string{routeName, "second-route"},
+					RouteURLs: []v1alpha1.RouteMonitorURL{
+						{
+							RouteName: routeName,
+							URL:       "http://" + routeMonitorRouteURLDefault,
+							Scheme:    "http",
+							Host:      routeMonitorRouteURLDefault,
+						},
+						{
+							RouteName: "second-route",
+							URL:       "http://second-host",
+							Scheme:    "http",
+							Host:      "second-host",
+						},
+					},
+				}
+				mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Eq(&expectedRouteMonitor)).Times(1).Return(nil)
+			})
+			It("should list the urls of every Route", func() {
+				// Act
+				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, []routev1.Route{route, secondRoute}, routeMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.StopOperation()))
+			})
+		})
+		When("the Route is secured by TLS and has a path", func() {
+			// Arrange
+			BeforeEach(func() {
+				ingresses = []string{
+					routeMonitorRouteURLDefault,
+				}
+				routeSpec = routev1.RouteSpec{
+					Path: "/api",
+					TLS:  &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge},
+				}
+
+				mockClient.EXPECT().Status().Return(mockStatusWriter).Times(1)
+				routeMonitorSupplementClient = mockClient
+			})
+			JustBeforeEach(func() {
+				expectedRouteMonitor.Status = v1alpha1.RouteMonitorStatus{
+					Routes: []string{routeName},
+					RouteURLs: []v1alpha1.RouteMonitorURL{
+						{
+							RouteName: routeName,
+							URL:       "https://" + routeMonitorRouteURLDefault + "/api",
+							Scheme:    "https",
+							Host:      routeMonitorRouteURLDefault,
+							Path:      "/api",
+						},
+					},
+				}
+				mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Eq(&expectedRouteMonitor)).Times(1).Return(nil)
+			})
+			It("should probe https on the path of the Route", func() {
+				// Act
+				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, []routev1.Route{route}, routeMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.StopOperation()))
+			})
+		})
+		When("the RouteMonitor has a HealthPath", func() {
+			// Arrange
+			BeforeEach(func() {
+				ingresses = []string{
+					routeMonitorRouteURLDefault,
 				}
+				routeSpec = routev1.RouteSpec{
+					Path: "/api",
+				}
+
+				mockClient.EXPECT().Status().Return(mockStatusWriter).Times(1)
 				routeMonitorSupplementClient = mockClient
 			})
 			JustBeforeEach(func() {
-				expectedRouteMonitor.Status.RouteURL = routeMonitorRouteURLDefault
+				routeMonitor.Spec.HealthPath = "healthz"
+				expectedRouteMonitor.Spec.HealthPath = "healthz"
+				expectedRouteMonitor.Status = v1alpha1.RouteMonitorStatus{
+					Routes: []string{routeName},
+					RouteURLs: []v1alpha1.RouteMonitorURL{
+						{
+							RouteName: routeName,
+							URL:       "http://" + routeMonitorRouteURLDefault + "/api/healthz",
+							Scheme:    "http",
+							Host:      routeMonitorRouteURLDefault,
+							Path:      "/api/healthz",
+						},
+					},
+				}
+				mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Eq(&expectedRouteMonitor)).Times(1).Return(nil)
+			})
+			It("should append the HealthPath to the path of the Route", func() {
+				// Act
+				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, []routev1.Route{route}, routeMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.StopOperation()))
+			})
+		})
+		When("the RouteMonitor probes a url", func() {
+			// Arrange
+			BeforeEach(func() {
+				mockClient.EXPECT().Status().Return(mockStatusWriter).Times(1)
+				routeMonitorSupplementClient = mockClient
+			})
+			JustBeforeEach(func() {
+				routeMonitor.Spec.URL = "https://example.com/status"
+				routeMonitor.Spec.HealthPath = "healthz"
+				expectedRouteMonitor.Spec = routeMonitor.Spec
+				expectedRouteMonitor.Status = v1alpha1.RouteMonitorStatus{
+					RouteURLs: []v1alpha1.RouteMonitorURL{
+						{
+							URL:    "https://example.com/status/healthz",
+							Scheme: "https",
+							Host:   "example.com",
+							Path:   "/status/healthz",
+						},
+					},
+				}
+				mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Eq(&expectedRouteMonitor)).Times(1).Return(nil)
+			})
+			It("should take the url without any Route", func() {
+				// Act
+				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, nil, routeMonitor)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.StopOperation()))
+			})
+		})
+		When("the Route has the same RouteURL as the extracted one", func() {
+			// Arrange
+			BeforeEach(func() {
+				ingresses = []string{
+					routeMonitorRouteURLDefault,
+				}
+
+				routeMonitorStatus = v1alpha1.RouteMonitorStatus{
+					Routes: []string{routeName},
+					RouteURLs: []v1alpha1.RouteMonitorURL{
+						{
+							RouteName: routeName,
+							URL:       "http://" + routeMonitorRouteURLDefault,
+							Scheme:    "http",
+							Host:      routeMonitorRouteURLDefault,
+						},
+					},
+				}
+				routeMonitorSupplementClient = mockClient
 			})
 			It("should skip this operation", func() {
 				// Act
-				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, route, routeMonitor)
+				res, err := routeMonitorSupplement.EnsureRouteURLExists(ctx, []routev1.Route{route}, routeMonitor)
 				// Assert
 				Expect(err).NotTo(HaveOccurred())
 				Expect(res).To(Equal(utilreconcile.ContinueOperation()))
@@ -502,6 +846,12 @@ func ConvertToIngressHosts(in []string) []routev1.RouteIngress {
 	for i, s := range in {
 		res[i] = routev1.RouteIngress{
 			Host: s,
+			Conditions: []routev1.RouteIngressCondition{
+				{
+					Type:   routev1.RouteAdmitted,
+					Status: corev1.ConditionTrue,
+				},
+			},
 		}
 	}
 	return res
@@ -0,0 +1,129 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagemigration
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/route-monitor-operator/api/v1beta1"
+)
+
+// RouteMonitorCRDName is the name of the CustomResourceDefinition of the RouteMonitors
+const RouteMonitorCRDName = "routemonitors.monitoring.openshift.io"
+
+// retryInterval is how long the migration waits before it is retried, e.g. while the conversion webhook is not served yet
+const retryInterval = 30 * time.Second
+
+// StorageVersionMigrator rewrites the RouteMonitors that are stored in an older version of the API
+// into the storage version, so the older version can be dropped from the stored versions of the CRD
+type StorageVersionMigrator struct {
+	client.Client
+	// APIReader reads around the cache, so no informer is started for the CRDs
+	APIReader client.Reader
+	Log       logr.Logger
+}
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=update;patch
+
+// Start implements manager.Runnable, it migrates the RouteMonitors once and retries until it succeeded
+func (m *StorageVersionMigrator) Start(stop <-chan struct{}) error {
+	ctx := context.Background()
+	err := wait.PollImmediateUntil(retryInterval, func() (bool, error) {
+		if err := m.Migrate(ctx); err != nil {
+			// the RouteMonitors can still be reconciled, only the older version stays stored
+			m.Log.Error(err, "Failed to migrate the stored RouteMonitors, retrying")
+			return false, nil
+		}
+		return true, nil
+	}, stop)
+	if err == wait.ErrWaitTimeout {
+		// the manager was stopped before the migration succeeded
+		return nil
+	}
+	return err
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so only one replica rewrites the RouteMonitors
+func (m *StorageVersionMigrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Migrate rewrites every RouteMonitor and then leaves only the storage version in the stored versions of the CRD
+func (m *StorageVersionMigrator) Migrate(ctx context.Context) error {
+	crd := apiextensionsv1.CustomResourceDefinition{}
+	if err := m.APIReader.Get(ctx, types.NamespacedName{Name: RouteMonitorCRDName}, &crd); err != nil {
+		return err
+	}
+	storageVersion, err := storageVersionOf(crd)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(crd.Status.StoredVersions, []string{storageVersion}) {
+		m.Log.V(2).Info("Skipping migration: the RouteMonitors are only stored in the storage version", "version", storageVersion)
+		return nil
+	}
+
+	routeMonitors := v1beta1.RouteMonitorList{}
+	if err := m.APIReader.List(ctx, &routeMonitors); err != nil {
+		return err
+	}
+	rejected := 0
+	for i := range routeMonitors.Items {
+		routeMonitor := &routeMonitors.Items[i]
+		// an update without changes is enough, the API server writes the object in the storage version.
+		// A RouteMonitor that was changed or deleted in the meantime was written by someone else already
+		err := m.Update(ctx, routeMonitor)
+		if k8serrors.IsForbidden(err) || k8serrors.IsInvalid(err) {
+			// a RouteMonitor rejected by an admission webhook must not keep the others from being migrated
+			m.Log.Error(err, "Skipping the migration of a RouteMonitor that was rejected", "Namespace", routeMonitor.Namespace, "Name", routeMonitor.Name)
+			rejected++
+			continue
+		}
+		if err != nil && !k8serrors.IsConflict(err) && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("cannot migrate RouteMonitor %s/%s: %w", routeMonitor.Namespace, routeMonitor.Name, err)
+		}
+	}
+	if rejected > 0 {
+		// the rejected RouteMonitors are still stored in the older version, so it has to stay in the stored versions
+		return fmt.Errorf("%d of %d RouteMonitors were rejected, keeping the stored versions %v", rejected, len(routeMonitors.Items), crd.Status.StoredVersions)
+	}
+
+	m.Log.V(1).Info("Migrated the stored RouteMonitors", "count", len(routeMonitors.Items), "version", storageVersion)
+	crd.Status.StoredVersions = []string{storageVersion}
+	return m.Status().Update(ctx, &crd)
+}
+
+// storageVersionOf returns the version of the CRD that objects are stored in
+func storageVersionOf(crd apiextensionsv1.CustomResourceDefinition) (string, error) {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name, nil
+		}
+	}
+	return "", fmt.Errorf("CustomResourceDefinition %s has no storage version", crd.Name)
+}
@@ -0,0 +1,13 @@
+package storagemigration_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestStorageMigration(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "StorageMigration Suite")
+}
@@ -0,0 +1,154 @@
+package storagemigration_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	//tested package
+	"github.com/openshift/route-monitor-operator/controllers/storagemigration"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/route-monitor-operator/api/v1beta1"
+	constinit "github.com/openshift/route-monitor-operator/pkg/const/test/init"
+)
+
+// rejectingClient rejects the updates of one RouteMonitor like an admission webhook does
+type rejectingClient struct {
+	client.Client
+	rejectedName string
+}
+
+func (c rejectingClient) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	if routeMonitor, ok := obj.(*v1beta1.RouteMonitor); ok && routeMonitor.Name == c.rejectedName {
+		return k8serrors.NewForbidden(v1beta1.GroupVersion.WithResource("routemonitors").GroupResource(), routeMonitor.Name, fmt.Errorf("admission webhook denied the request"))
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+var _ = Describe("StorageMigration", func() {
+	var (
+		ctx             context.Context
+		migrator        storagemigration.StorageVersionMigrator
+		migrationClient client.Client
+		rejectedName    string
+		storedVersions  []string
+		crdVersions     []apiextensionsv1.CustomResourceDefinitionVersion
+		getCRD          func() apiextensionsv1.CustomResourceDefinition
+		getRouteMonitor func() v1beta1.RouteMonitor
+	)
+	BeforeEach(func() {
+		ctx = constinit.Context
+		rejectedName = ""
+		storedVersions = []string{"v1alpha1", "v1beta1"}
+		crdVersions = []apiextensionsv1.CustomResourceDefinitionVersion{
+			{Name: "v1alpha1", Served: true},
+			{Name: "v1beta1", Served: true, Storage: true},
+		}
+		getCRD = func() apiextensionsv1.CustomResourceDefinition {
+			crd := apiextensionsv1.CustomResourceDefinition{}
+			Expect(migrationClient.Get(ctx, types.NamespacedName{Name: storagemigration.RouteMonitorCRDName}, &crd)).To(Succeed())
+			return crd
+		}
+		getRouteMonitor = func() v1beta1.RouteMonitor {
+			routeMonitor := v1beta1.RouteMonitor{}
+			Expect(migrationClient.Get(ctx, types.NamespacedName{Name: "first", Namespace: "shop"}, &routeMonitor)).To(Succeed())
+			return routeMonitor
+		}
+	})
+	JustBeforeEach(func() {
+		objects := []runtime.Object{
+			&apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: storagemigration.RouteMonitorCRDName},
+				Spec:       apiextensionsv1.CustomResourceDefinitionSpec{Versions: crdVersions},
+				Status:     apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: storedVersions},
+			},
+			&v1beta1.RouteMonitor{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "shop"}},
+			&v1beta1.RouteMonitor{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "payments"}},
+		}
+		migrationClient = fake.NewFakeClientWithScheme(constinit.Scheme, objects...)
+		migrator = storagemigration.StorageVersionMigrator{
+			Client:    rejectingClient{Client: migrationClient, rejectedName: rejectedName},
+			APIReader: migrationClient,
+			Log:       constinit.Logger,
+		}
+	})
+
+	Describe("Migrate", func() {
+		When("the RouteMonitors are stored in an older version", func() {
+			It("should leave only the storage version in the stored versions", func() {
+				// Act
+				err := migrator.Migrate(ctx)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getCRD().Status.StoredVersions).To(Equal([]string{"v1beta1"}))
+			})
+			It("should rewrite every RouteMonitor", func() {
+				// Arrange
+				resourceVersion := getRouteMonitor().ResourceVersion
+				// Act
+				err := migrator.Migrate(ctx)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				// the fake client bumps the resourceVersion on every write
+				Expect(getRouteMonitor().ResourceVersion).NotTo(Equal(resourceVersion))
+			})
+		})
+		When("a RouteMonitor is rejected by the webhook", func() {
+			BeforeEach(func() {
+				rejectedName = "second"
+			})
+			It("should still rewrite the other RouteMonitors", func() {
+				// Arrange
+				resourceVersion := getRouteMonitor().ResourceVersion
+				// Act
+				err := migrator.Migrate(ctx)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(getRouteMonitor().ResourceVersion).NotTo(Equal(resourceVersion))
+			})
+			It("should return an error and keep the stored versions", func() {
+				// Act
+				err := migrator.Migrate(ctx)
+				// Assert
+				Expect(err).To(MatchError(ContainSubstring("1 of 2 RouteMonitors were rejected")))
+				Expect(getCRD().Status.StoredVersions).To(Equal([]string{"v1alpha1", "v1beta1"}))
+			})
+		})
+		When("the RouteMonitors are only stored in the storage version", func() {
+			BeforeEach(func() {
+				storedVersions = []string{"v1beta1"}
+			})
+			It("should not rewrite the RouteMonitors", func() {
+				// Arrange
+				resourceVersion := getRouteMonitor().ResourceVersion
+				// Act
+				err := migrator.Migrate(ctx)
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getRouteMonitor().ResourceVersion).To(Equal(resourceVersion))
+			})
+		})
+		When("the CRD has no storage version", func() {
+			BeforeEach(func() {
+				crdVersions = []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1alpha1", Served: true}}
+			})
+			It("should return an error and keep the stored versions", func() {
+				// Act
+				err := migrator.Migrate(ctx)
+				// Assert
+				Expect(err).To(HaveOccurred())
+				Expect(getCRD().Status.StoredVersions).To(Equal([]string{"v1alpha1", "v1beta1"}))
+			})
+		})
+	})
+})
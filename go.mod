@@ -10,8 +10,11 @@ require (
 	github.com/onsi/gomega v1.10.1
 	github.com/openshift/api v3.9.0+incompatible
 	github.com/prometheus-operator/prometheus-operator v0.41.1-0.20200806133437-e7d55e3fea24
+	github.com/prometheus/blackbox_exporter v0.18.0
 	k8s.io/api v0.18.6
+	k8s.io/apiextensions-apiserver v0.18.6
 	k8s.io/apimachinery v0.18.6
 	k8s.io/client-go v0.18.6
 	sigs.k8s.io/controller-runtime v0.6.3
+	sigs.k8s.io/yaml v1.2.0
 )
@@ -0,0 +1,27 @@
+#!/bin/bash
+
+# Pins the default images of the operator to the digest of their tag,
+# both in pkg/const/blackbox and in the RELATED_IMAGE_* variables of the manager Deployment
+
+set -e
+
+if [[ ! $(which skopeo) ]]; then
+	echo "required binary skopeo does not exist on machine, exiting"
+	exit 1
+fi
+
+CONSTS_FILE="pkg/const/blackbox/blackbox.go"
+MANAGER_FILE="config/manager/manager.yaml"
+
+for const in "$@"; do
+	image=$(sed -n "s|^\s*${const}\s*=\s*\"\(.*\)\"$|\1|p" "${CONSTS_FILE}")
+	if [[ -z ${image} ]]; then
+		echo "no image constant ${const} in ${CONSTS_FILE}, exiting"
+		exit 1
+	fi
+	tagged="${image%%@*}"
+	digest=$(skopeo inspect --format '{{.Digest}}' "docker://${tagged}")
+	echo "pinning ${tagged} to ${digest}"
+	sed -i "s|\"${image}\"|\"${tagged}@${digest}\"|" "${CONSTS_FILE}"
+	sed -i "s|value: ${image}$|value: ${tagged}@${digest}|" "${MANAGER_FILE}"
+done
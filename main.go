@@ -20,6 +20,7 @@ import (
 	"flag"
 	"os"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -32,7 +33,12 @@ import (
 
 	monitoringopenshiftiov1alpha1 "github.com/openshift/route-monitor-operator/api/v1alpha1"
 	monitoringv1alpha1 "github.com/openshift/route-monitor-operator/api/v1alpha1"
+	monitoringv1beta1 "github.com/openshift/route-monitor-operator/api/v1beta1"
+	"github.com/openshift/route-monitor-operator/controllers/clusterroutemonitor"
+	"github.com/openshift/route-monitor-operator/controllers/route"
 	"github.com/openshift/route-monitor-operator/controllers/routemonitor"
+	"github.com/openshift/route-monitor-operator/controllers/storagemigration"
+	"github.com/openshift/route-monitor-operator/pkg/const/blackbox"
 
 	"github.com/openshift/route-monitor-operator/controllers/routemonitor/adder"
 	"github.com/openshift/route-monitor-operator/controllers/routemonitor/deleter"
@@ -53,6 +59,8 @@ func init() {
 	utilruntime.Must(routev1.AddToScheme(scheme))
 
 	utilruntime.Must(monitoringopenshiftiov1alpha1.AddToScheme(scheme))
+	utilruntime.Must(monitoringv1beta1.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -64,12 +72,47 @@ func main() {
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 
+	var blackBoxNamespace string
+	var serviceMonitorPlacement string
+	var blackBoxImage string
+	var kubeRBACProxyImage string
+	flag.StringVar(&blackBoxNamespace, "blackbox-exporter-namespace", blackbox.DefaultBlackBoxNamespace,
+		"The namespace the blackbox exporter is deployed to.")
+	flag.StringVar(&serviceMonitorPlacement, "servicemonitor-placement", blackbox.ServiceMonitorInExporterNamespace,
+		"Where the ServiceMonitors are created, '"+blackbox.ServiceMonitorInExporterNamespace+"' puts them next to the blackbox exporter, "+
+			"'"+blackbox.ServiceMonitorInRouteMonitorNamespace+"' next to their RouteMonitor, which needs a Prometheus that honours the namespace selectors of ServiceMonitors.")
+	defaultBlackBoxImage := blackbox.DefaultBlackBoxImage
+	if image, ok := os.LookupEnv(blackbox.BlackBoxImageEnvVar); ok {
+		defaultBlackBoxImage = image
+	}
+	flag.StringVar(&blackBoxImage, "blackbox-exporter-image", defaultBlackBoxImage,
+		"The image of the blackbox exporter, defaults to $"+blackbox.BlackBoxImageEnvVar+" when it is set.")
+	defaultKubeRBACProxyImage := blackbox.DefaultKubeRBACProxyImage
+	if image, ok := os.LookupEnv(blackbox.KubeRBACProxyImageEnvVar); ok {
+		defaultKubeRBACProxyImage = image
+	}
+	flag.StringVar(&kubeRBACProxyImage, "kube-rbac-proxy-image", defaultKubeRBACProxyImage,
+		"The image of kube-rbac-proxy in front of the blackbox exporter, defaults to $"+blackbox.KubeRBACProxyImageEnvVar+" when it is set.")
+
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if err := blackbox.Configure(blackBoxNamespace, serviceMonitorPlacement); err != nil {
+		setupLog.Error(err, "invalid configuration of the blackbox exporter")
+		os.Exit(1)
+	}
+	if err := blackbox.ConfigureImage(blackBoxImage); err != nil {
+		setupLog.Error(err, "invalid configuration of the blackbox exporter")
+		os.Exit(1)
+	}
+	if err := blackbox.ConfigureKubeRBACProxyImage(kubeRBACProxyImage); err != nil {
+		setupLog.Error(err, "invalid configuration of the blackbox exporter")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
@@ -97,6 +140,45 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&clusterroutemonitor.ClusterRouteMonitorReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("ClusterRouteMonitor"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterRouteMonitor")
+		os.Exit(1)
+	}
+
+	if err = (&route.RouteReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("Route"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("route-monitor-operator"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Route")
+		os.Exit(1)
+	}
+
+	// the webhooks need the serving certificates, which are not around when running the operator locally
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&monitoringv1alpha1.RouteMonitor{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RouteMonitor")
+			os.Exit(1)
+		}
+		if err = (&monitoringv1beta1.RouteMonitor{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RouteMonitor", "version", "v1beta1")
+			os.Exit(1)
+		}
+	}
+
+	if err = mgr.Add(&storagemigration.StorageVersionMigrator{
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Log:       ctrl.Log.WithName("StorageVersionMigrator"),
+	}); err != nil {
+		setupLog.Error(err, "unable to add the storage version migration")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.V(2).Info("starting manager")
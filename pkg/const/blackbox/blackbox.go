@@ -1,18 +1,131 @@
 package blackbox
 
-import "k8s.io/apimachinery/pkg/types"
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
 
 const ( // All things related BlackBoxExporter
-	BlackBoxNamespace  = "openshift-monitoring"
-	BlackBoxName       = "blackbox-exporter"
-	BlackBoxPortName   = "blackbox"
-	BlackBoxPortNumber = 9115
+	// DefaultBlackBoxNamespace is where the exporter runs unless the operator is configured otherwise
+	DefaultBlackBoxNamespace = "openshift-monitoring"
+	BlackBoxName             = "blackbox-exporter"
+	BlackBoxPortName         = "blackbox"
+	BlackBoxPortNumber       = 9115
+
+	BlackBoxConfigKey            = "config.yml"
+	BlackBoxConfigMountPath      = "/etc/blackbox_exporter"
+	BlackBoxConfigHashAnnotation = "routemonitor.openshift.io/config-hash"
+	BlackBoxHealthPath           = "/-/healthy"
+
+	// PrometheusPodLabel is set on every Prometheus pod by the Prometheus Operator, only these pods may reach the exporter
+	PrometheusPodLabel = "prometheus"
+	// ClusterMonitoringNamespaceLabel marks the namespaces of the Cluster Monitoring Operator, the Prometheus pods have to be in one of them
+	ClusterMonitoringNamespaceLabel = "openshift.io/cluster-monitoring"
+)
+
+const ( // All things related to the kube-rbac-proxy in front of the exporter
+	KubeRBACProxyName = "kube-rbac-proxy"
+	// BlackBoxUpstreamPortNumber is where the exporter listens on localhost when kube-rbac-proxy serves BlackBoxPortNumber
+	BlackBoxUpstreamPortNumber = 9116
+
+	// ServingCertSecretAnnotation lets the OpenShift service CA issue the certificate of the exporter Service
+	ServingCertSecretAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
+	BlackBoxTLSSecretName       = "blackbox-exporter-tls"
+	BlackBoxTLSMountPath        = "/etc/tls/private"
+
+	// DefaultServingCAFile is where the Prometheus of the Cluster Monitoring Operator finds the service CA
+	DefaultServingCAFile = "/etc/prometheus/configmaps/serving-certs-ca-bundle/service-ca.crt"
+	// ServiceAccountTokenFile is the token Prometheus authenticates with against kube-rbac-proxy
+	ServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+const ( // Defaults of the exporter deployment, unless the RouteMonitorOperatorConfig sets them
+	// DefaultBlackBoxImage is a released version, so the exporter doesn't change under the operator and can be mirrored for disconnected clusters.
+	// TODO: pin it to the digest of its tag with `make pin-images`, which needs access to quay.io; until then it refers to the tag
+	DefaultBlackBoxImage = "quay.io/prometheus/blackbox-exporter:v0.18.0"
+	// DefaultBlackBoxReplicas keeps the probes running while a node of the exporter is drained
+	DefaultBlackBoxReplicas int32 = 2
+
+	// BlackBoxImageEnvVar holds the image of the exporter, it is set from the relatedImages of the OLM bundle
+	BlackBoxImageEnvVar = "RELATED_IMAGE_BLACKBOX_EXPORTER"
+
+	// DefaultKubeRBACProxyImage is a released version, `make pin-images` pins it together with DefaultBlackBoxImage
+	DefaultKubeRBACProxyImage = "quay.io/brancz/kube-rbac-proxy:v0.8.0"
+	KubeRBACProxyImageEnvVar  = "RELATED_IMAGE_KUBE_RBAC_PROXY"
+)
+
+const ( // Defaults for the probes of a RouteMonitor
+	DefaultInterval      = "30s"
+	DefaultScrapeTimeout = "15s"
+	DefaultModule        = "http_2xx"
+)
+
+const ( // The alert of a RouteMonitor with alerting
+	ProbeFailingAlertName = "RouteMonitorProbeFailing"
+	DefaultAlertFor       = "5m"
+	DefaultAlertSeverity  = "warning"
 )
 
-var ( // cannot be a const but doesn't ever change
-	BlackBoxNamespacedName = types.NamespacedName{Name: BlackBoxName, Namespace: BlackBoxNamespace}
+// Where the ServiceMonitors are created
+const (
+	// ServiceMonitorInExporterNamespace puts every ServiceMonitor next to the exporter, where cluster-monitoring-operator picks them up
+	ServiceMonitorInExporterNamespace = "exporter"
+	// ServiceMonitorInRouteMonitorNamespace puts each ServiceMonitor next to its RouteMonitor, for a Prometheus selecting other namespaces.
+	// The ServiceMonitors select the exporter Service across namespaces, so a Prometheus with ignoreNamespaceSelectors,
+	// like the one of user-workload monitoring, finds no targets; the Probe backend has no such selector
+	ServiceMonitorInRouteMonitorNamespace = "routemonitor"
 )
 
+var ( // set once on startup by Configure, before any resource is reconciled
+	BlackBoxNamespace       = DefaultBlackBoxNamespace
+	BlackBoxNamespacedName  = types.NamespacedName{Name: BlackBoxName, Namespace: BlackBoxNamespace}
+	ServiceMonitorPlacement = ServiceMonitorInExporterNamespace
+	BlackBoxImage           = DefaultBlackBoxImage
+	KubeRBACProxyImage      = DefaultKubeRBACProxyImage
+)
+
+// Configure sets the namespace of the exporter and where the ServiceMonitors are placed
+func Configure(namespace, serviceMonitorPlacement string) error {
+	if errs := validation.IsDNS1123Label(namespace); len(errs) != 0 {
+		return fmt.Errorf("invalid namespace of the blackbox exporter '%s': %s", namespace, strings.Join(errs, ", "))
+	}
+	switch serviceMonitorPlacement {
+	case ServiceMonitorInExporterNamespace, ServiceMonitorInRouteMonitorNamespace:
+	default:
+		return fmt.Errorf("invalid ServiceMonitor placement '%s', has to be '%s' or '%s'",
+			serviceMonitorPlacement, ServiceMonitorInExporterNamespace, ServiceMonitorInRouteMonitorNamespace)
+	}
+	BlackBoxNamespace = namespace
+	BlackBoxNamespacedName = types.NamespacedName{Name: BlackBoxName, Namespace: namespace}
+	ServiceMonitorPlacement = serviceMonitorPlacement
+	return nil
+}
+
+// ConfigureImage sets the image of the exporter, unless the RouteMonitorOperatorConfig overrides it
+func ConfigureImage(image string) error {
+	if !isValidImage(image) {
+		return fmt.Errorf("invalid image of the blackbox exporter '%s'", image)
+	}
+	BlackBoxImage = image
+	return nil
+}
+
+// ConfigureKubeRBACProxyImage sets the image of kube-rbac-proxy, unless the RouteMonitorOperatorConfig overrides it
+func ConfigureKubeRBACProxyImage(image string) error {
+	if !isValidImage(image) {
+		return fmt.Errorf("invalid image of kube-rbac-proxy '%s'", image)
+	}
+	KubeRBACProxyImage = image
+	return nil
+}
+
+func isValidImage(image string) bool {
+	return image != "" && !strings.ContainsAny(image, " \t\n")
+}
+
 // generateBlackBoxLables creates a set of common labels to most resources
 // this function is here in case we need more labels in the future
 func GenerateBlackBoxLables() map[string]string {
@@ -0,0 +1,37 @@
+package consts
+
+const (
+	// ClusterRouteMonitorLabel holds the name of the ClusterRouteMonitor that created a RouteMonitor
+	ClusterRouteMonitorLabel string = "routemonitor.openshift.io/cluster-route-monitor"
+)
+
+// Annotations on a Route that opt it in to monitoring
+const (
+	// RouteMonitoringEnabledAnnotation makes the operator create a RouteMonitor for the Route when it is "true"
+	RouteMonitoringEnabledAnnotation string = "routemonitor.openshift.io/enabled"
+	// RouteIntervalAnnotation sets the interval of the RouteMonitor
+	RouteIntervalAnnotation string = "routemonitor.openshift.io/interval"
+	// RouteScrapeTimeoutAnnotation sets the scrapeTimeout of the RouteMonitor
+	RouteScrapeTimeoutAnnotation string = "routemonitor.openshift.io/scrape-timeout"
+	// RouteModuleAnnotation sets the module of the RouteMonitor
+	RouteModuleAnnotation string = "routemonitor.openshift.io/module"
+	// RouteHealthPathAnnotation sets the healthPath of the RouteMonitor
+	RouteHealthPathAnnotation string = "routemonitor.openshift.io/health-path"
+)
+
+// Metadata of a ServiceMonitor pointing back to the RouteMonitor it was created for
+const (
+	// ServiceMonitorOwnerUIDLabel holds the UID of the RouteMonitor, a ServiceMonitor without it is never touched
+	ServiceMonitorOwnerUIDLabel string = "routemonitor.openshift.io/owner-uid"
+	// ServiceMonitorOwnerNamespaceAnnotation holds the namespace of the RouteMonitor
+	ServiceMonitorOwnerNamespaceAnnotation string = "routemonitor.openshift.io/owner-namespace"
+	// ServiceMonitorOwnerNameAnnotation holds the name of the RouteMonitor
+	ServiceMonitorOwnerNameAnnotation string = "routemonitor.openshift.io/owner-name"
+)
+
+// Annotations kept on a RouteMonitor while it is converted between the API versions
+const (
+	// ConvertedRouteNamespaceAnnotation holds the spec.route.namespace of a v1alpha1 RouteMonitor, which v1beta1 has no field for.
+	// It is only set when the namespace is not the one the conversion back to v1alpha1 fills in
+	ConvertedRouteNamespaceAnnotation string = "routemonitor.openshift.io/converted-route-namespace"
+)
@@ -0,0 +1,111 @@
+package blackboxconfig
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the configuration file of the blackbox exporter
+type Config struct {
+	Modules map[string]Module `json:"modules"`
+}
+
+// Module describes how the blackbox exporter probes a target
+type Module struct {
+	Prober  string     `json:"prober"`
+	Timeout string     `json:"timeout,omitempty"`
+	HTTP    *HTTPProbe `json:"http,omitempty"`
+	TCP     *TCPProbe  `json:"tcp,omitempty"`
+}
+
+// HTTPProbe holds the settings of the `http` prober
+type HTTPProbe struct {
+	Method                     string            `json:"method,omitempty"`
+	Headers                    map[string]string `json:"headers,omitempty"`
+	Body                       string            `json:"body,omitempty"`
+	ValidStatusCodes           []int             `json:"valid_status_codes,omitempty"`
+	FailIfBodyMatchesRegexp    []string          `json:"fail_if_body_matches_regexp,omitempty"`
+	FailIfBodyNotMatchesRegexp []string          `json:"fail_if_body_not_matches_regexp,omitempty"`
+	// NoFollowRedirects is inverted, as follow_redirects is unknown to the exporter before v0.19
+	NoFollowRedirects bool `json:"no_follow_redirects,omitempty"`
+}
+
+// TCPProbe holds the settings of the `tcp` prober
+type TCPProbe struct {
+	TLS bool `json:"tls,omitempty"`
+}
+
+// knownModules are the modules a RouteMonitor can pick, mirroring the example config of the blackbox exporter
+var knownModules = map[string]Module{
+	"http_2xx": {
+		Prober: "http",
+	},
+	"http_post_2xx": {
+		Prober: "http",
+		HTTP: &HTTPProbe{
+			Method: "POST",
+		},
+	},
+	"tcp_connect": {
+		Prober: "tcp",
+	},
+	"tls_connect": {
+		Prober: "tcp",
+		TCP: &TCPProbe{
+			TLS: true,
+		},
+	},
+}
+
+// Lookup returns the definition of a known module
+func Lookup(name string) (Module, bool) {
+	module, ok := knownModules[name]
+	return module, ok
+}
+
+// KnownModules returns the sorted names of all modules that can be used
+func KnownModules() []string {
+	names := make([]string, 0, len(knownModules))
+	for name := range knownModules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates a Config holding the given modules, unknown modules are returned separately
+func New(moduleNames ...string) (config Config, unknown []string) {
+	config = Config{Modules: map[string]Module{}}
+	for _, name := range moduleNames {
+		module, ok := Lookup(name)
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		config.Modules[name] = module
+	}
+	return config, unknown
+}
+
+// AddModule adds a module to the Config, replacing a module with the same name
+func (c Config) AddModule(name string, module Module) {
+	c.Modules[name] = module
+}
+
+// Render serializes the Config into the file format of the blackbox exporter
+func (c Config) Render() (string, error) {
+	// yaml.Marshal sorts map keys, so the same Config always renders to the same content
+	res, err := yaml.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
+// Hash returns a short digest of a rendered Config, used to roll the exporter when its config changes
+func Hash(renderedConfig string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(renderedConfig)))[:16]
+}
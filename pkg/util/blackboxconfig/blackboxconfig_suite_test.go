@@ -0,0 +1,13 @@
+package blackboxconfig_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestBlackboxconfig(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Blackboxconfig Suite")
+}
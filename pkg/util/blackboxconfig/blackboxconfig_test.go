@@ -0,0 +1,157 @@
+package blackboxconfig_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/route-monitor-operator/pkg/util/blackboxconfig"
+	exporterconfig "github.com/prometheus/blackbox_exporter/config"
+)
+
+var _ = Describe("Blackboxconfig", func() {
+	Describe("Lookup", func() {
+		When("the module is known", func() {
+			It("should return its definition", func() {
+				// Act
+				module, ok := blackboxconfig.Lookup("http_post_2xx")
+				// Assert
+				Expect(ok).To(BeTrue())
+				Expect(module.Prober).To(Equal("http"))
+				Expect(module.HTTP.Method).To(Equal("POST"))
+			})
+		})
+		When("the module is unknown", func() {
+			It("should report it", func() {
+				// Act
+				_, ok := blackboxconfig.Lookup("not_a_module")
+				// Assert
+				Expect(ok).To(BeFalse())
+			})
+		})
+	})
+	Describe("New", func() {
+		When("known and unknown modules are requested", func() {
+			It("should only hold the known ones and return the unknown ones", func() {
+				// Act
+				config, unknown := blackboxconfig.New("http_2xx", "tcp_connect", "not_a_module", "http_2xx")
+				// Assert
+				Expect(config.Modules).To(HaveLen(2))
+				Expect(config.Modules).To(HaveKey("http_2xx"))
+				Expect(config.Modules).To(HaveKey("tcp_connect"))
+				Expect(unknown).To(Equal([]string{"not_a_module"}))
+			})
+		})
+	})
+	Describe("AddModule", func() {
+		When("a module is added", func() {
+			It("should be part of the config", func() {
+				// Arrange
+				config, _ := blackboxconfig.New()
+				module := blackboxconfig.Module{Prober: "http", HTTP: &blackboxconfig.HTTPProbe{Method: "HEAD"}}
+				// Act
+				config.AddModule("custom", module)
+				// Assert
+				Expect(config.Modules).To(Equal(map[string]blackboxconfig.Module{"custom": module}))
+			})
+		})
+	})
+	Describe("Render", func() {
+		When("a config is rendered", func() {
+			It("should produce the blackbox exporter file format", func() {
+				// Arrange
+				config, _ := blackboxconfig.New("tls_connect", "http_2xx")
+				// Act
+				res, err := config.Render()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(`modules:
+  http_2xx:
+    prober: http
+  tls_connect:
+    prober: tcp
+    tcp:
+      tls: true
+`))
+			})
+		})
+	})
+	Describe("Render with http settings", func() {
+		When("a module with http settings is rendered", func() {
+			It("should use the field names of the blackbox exporter", func() {
+				// Arrange
+				config, _ := blackboxconfig.New()
+				config.AddModule("custom", blackboxconfig.Module{
+					Prober: "http",
+					HTTP: &blackboxconfig.HTTPProbe{
+						ValidStatusCodes:           []int{200},
+						FailIfBodyNotMatchesRegexp: []string{"ok"},
+						NoFollowRedirects:          true,
+					},
+				})
+				// Act
+				res, err := config.Render()
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(`modules:
+  custom:
+    http:
+      fail_if_body_not_matches_regexp:
+      - ok
+      no_follow_redirects: true
+      valid_status_codes:
+      - 200
+    prober: http
+`))
+			})
+		})
+	})
+	Describe("Render for the exporter", func() {
+		When("every setting is rendered", func() {
+			It("should be loaded by the pinned exporter, which rejects unknown fields", func() {
+				// Arrange
+				config, _ := blackboxconfig.New(blackboxconfig.KnownModules()...)
+				config.AddModule("custom", blackboxconfig.Module{
+					Prober:  "http",
+					Timeout: "5s",
+					HTTP: &blackboxconfig.HTTPProbe{
+						Method:                     "HEAD",
+						Headers:                    map[string]string{"Accept": "text/html"},
+						Body:                       "{}",
+						ValidStatusCodes:           []int{200},
+						FailIfBodyMatchesRegexp:    []string{"error"},
+						FailIfBodyNotMatchesRegexp: []string{"ok"},
+						NoFollowRedirects:          true,
+					},
+				})
+				res, err := config.Render()
+				Expect(err).NotTo(HaveOccurred())
+				file, err := ioutil.TempFile("", "blackbox-*.yml")
+				Expect(err).NotTo(HaveOccurred())
+				defer os.Remove(file.Name())
+				_, err = file.WriteString(res)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(file.Close()).To(Succeed())
+				// Act
+				err = (&exporterconfig.SafeConfig{}).ReloadConfig(file.Name())
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+	Describe("Hash", func() {
+		When("two configs are hashed", func() {
+			It("should only match for the same content", func() {
+				// Act
+				first := blackboxconfig.Hash("modules: {}")
+				second := blackboxconfig.Hash("modules: {}")
+				third := blackboxconfig.Hash("modules: {http_2xx: {prober: http}}")
+				// Assert
+				Expect(first).To(Equal(second))
+				Expect(first).NotTo(Equal(third))
+			})
+		})
+	})
+})
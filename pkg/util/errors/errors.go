@@ -5,5 +5,11 @@ import (
 )
 
 var (
-	NoHost = errors.New("No Host: extracted RouteURL is empty")
+	NoHost    = errors.New("No Host: extracted RouteURL is empty")
+	NoIngress = errors.New("No Ingress: cannot extract route url from the Route resource as no ingress is admitted")
+	NoRoute   = errors.New("No Route: the routeSelector does not match any Route")
+	// ForeignResource is returned when a ServiceMonitor, Probe or PrometheusRule of a RouteMonitor exists but was not created for it
+	ForeignResource = errors.New("Foreign resource: the resource exists but does not belong to the RouteMonitor")
+	// InvalidCR is wrapped by the errors caused by the spec of the CR, which a retry cannot fix
+	InvalidCR = errors.New("Invalid CR")
 )
@@ -38,6 +38,21 @@ func (m *MockRouteMonitorSupplement) EXPECT() *MockRouteMonitorSupplementMockRec
 	return m.recorder
 }
 
+// GetOperatorConfig mocks base method
+func (m *MockRouteMonitorSupplement) GetOperatorConfig(ctx context.Context) (v1alpha1.RouteMonitorOperatorConfigSpec, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperatorConfig", ctx)
+	ret0, _ := ret[0].(v1alpha1.RouteMonitorOperatorConfigSpec)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperatorConfig indicates an expected call of GetOperatorConfig
+func (mr *MockRouteMonitorSupplementMockRecorder) GetOperatorConfig(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperatorConfig", reflect.TypeOf((*MockRouteMonitorSupplement)(nil).GetOperatorConfig), ctx)
+}
+
 // GetRouteMonitor mocks base method
 func (m *MockRouteMonitorSupplement) GetRouteMonitor(ctx context.Context, req controllerruntime.Request) (v1alpha1.RouteMonitor, reconcile.Result, error) {
 	m.ctrl.T.Helper()
@@ -54,34 +69,34 @@ func (mr *MockRouteMonitorSupplementMockRecorder) GetRouteMonitor(ctx, req inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouteMonitor", reflect.TypeOf((*MockRouteMonitorSupplement)(nil).GetRouteMonitor), ctx, req)
 }
 
-// GetRoute mocks base method
-func (m *MockRouteMonitorSupplement) GetRoute(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) (v1.Route, error) {
+// GetRoutes mocks base method
+func (m *MockRouteMonitorSupplement) GetRoutes(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) ([]v1.Route, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRoute", ctx, routeMonitor)
-	ret0, _ := ret[0].(v1.Route)
+	ret := m.ctrl.Call(m, "GetRoutes", ctx, routeMonitor)
+	ret0, _ := ret[0].([]v1.Route)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRoute indicates an expected call of GetRoute
-func (mr *MockRouteMonitorSupplementMockRecorder) GetRoute(ctx, routeMonitor interface{}) *gomock.Call {
+// GetRoutes indicates an expected call of GetRoutes
+func (mr *MockRouteMonitorSupplementMockRecorder) GetRoutes(ctx, routeMonitor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoute", reflect.TypeOf((*MockRouteMonitorSupplement)(nil).GetRoute), ctx, routeMonitor)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoutes", reflect.TypeOf((*MockRouteMonitorSupplement)(nil).GetRoutes), ctx, routeMonitor)
 }
 
 // EnsureRouteURLExists mocks base method
-func (m *MockRouteMonitorSupplement) EnsureRouteURLExists(ctx context.Context, route v1.Route, routeMonitor v1alpha1.RouteMonitor) (reconcile.Result, error) {
+func (m *MockRouteMonitorSupplement) EnsureRouteURLExists(ctx context.Context, routes []v1.Route, routeMonitor v1alpha1.RouteMonitor) (reconcile.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnsureRouteURLExists", ctx, route, routeMonitor)
+	ret := m.ctrl.Call(m, "EnsureRouteURLExists", ctx, routes, routeMonitor)
 	ret0, _ := ret[0].(reconcile.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EnsureRouteURLExists indicates an expected call of EnsureRouteURLExists
-func (mr *MockRouteMonitorSupplementMockRecorder) EnsureRouteURLExists(ctx, route, routeMonitor interface{}) *gomock.Call {
+func (mr *MockRouteMonitorSupplementMockRecorder) EnsureRouteURLExists(ctx, routes, routeMonitor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureRouteURLExists", reflect.TypeOf((*MockRouteMonitorSupplement)(nil).EnsureRouteURLExists), ctx, route, routeMonitor)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureRouteURLExists", reflect.TypeOf((*MockRouteMonitorSupplement)(nil).EnsureRouteURLExists), ctx, routes, routeMonitor)
 }
 
 // EnsureFinalizerAbsent mocks base method
@@ -165,6 +180,62 @@ func (mr *MockRouteMonitorDeleterMockRecorder) EnsureBlackBoxExporterServiceAbse
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterServiceAbsent", reflect.TypeOf((*MockRouteMonitorDeleter)(nil).EnsureBlackBoxExporterServiceAbsent), ctx)
 }
 
+// EnsureBlackBoxExporterPodDisruptionBudgetAbsent mocks base method
+func (m *MockRouteMonitorDeleter) EnsureBlackBoxExporterPodDisruptionBudgetAbsent(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureBlackBoxExporterPodDisruptionBudgetAbsent", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureBlackBoxExporterPodDisruptionBudgetAbsent indicates an expected call of EnsureBlackBoxExporterPodDisruptionBudgetAbsent
+func (mr *MockRouteMonitorDeleterMockRecorder) EnsureBlackBoxExporterPodDisruptionBudgetAbsent(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterPodDisruptionBudgetAbsent", reflect.TypeOf((*MockRouteMonitorDeleter)(nil).EnsureBlackBoxExporterPodDisruptionBudgetAbsent), ctx)
+}
+
+// EnsureBlackBoxExporterNetworkPolicyAbsent mocks base method
+func (m *MockRouteMonitorDeleter) EnsureBlackBoxExporterNetworkPolicyAbsent(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureBlackBoxExporterNetworkPolicyAbsent", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureBlackBoxExporterNetworkPolicyAbsent indicates an expected call of EnsureBlackBoxExporterNetworkPolicyAbsent
+func (mr *MockRouteMonitorDeleterMockRecorder) EnsureBlackBoxExporterNetworkPolicyAbsent(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterNetworkPolicyAbsent", reflect.TypeOf((*MockRouteMonitorDeleter)(nil).EnsureBlackBoxExporterNetworkPolicyAbsent), ctx)
+}
+
+// EnsureBlackBoxExporterServiceAccountAbsent mocks base method
+func (m *MockRouteMonitorDeleter) EnsureBlackBoxExporterServiceAccountAbsent(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureBlackBoxExporterServiceAccountAbsent", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureBlackBoxExporterServiceAccountAbsent indicates an expected call of EnsureBlackBoxExporterServiceAccountAbsent
+func (mr *MockRouteMonitorDeleterMockRecorder) EnsureBlackBoxExporterServiceAccountAbsent(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterServiceAccountAbsent", reflect.TypeOf((*MockRouteMonitorDeleter)(nil).EnsureBlackBoxExporterServiceAccountAbsent), ctx)
+}
+
+// EnsureBlackBoxExporterConfigMapAbsent mocks base method
+func (m *MockRouteMonitorDeleter) EnsureBlackBoxExporterConfigMapAbsent(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureBlackBoxExporterConfigMapAbsent", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureBlackBoxExporterConfigMapAbsent indicates an expected call of EnsureBlackBoxExporterConfigMapAbsent
+func (mr *MockRouteMonitorDeleterMockRecorder) EnsureBlackBoxExporterConfigMapAbsent(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterConfigMapAbsent", reflect.TypeOf((*MockRouteMonitorDeleter)(nil).EnsureBlackBoxExporterConfigMapAbsent), ctx)
+}
+
 // EnsureServiceMonitorResourceAbsent mocks base method
 func (m *MockRouteMonitorDeleter) EnsureServiceMonitorResourceAbsent(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) error {
 	m.ctrl.T.Helper()
@@ -179,6 +250,20 @@ func (mr *MockRouteMonitorDeleterMockRecorder) EnsureServiceMonitorResourceAbsen
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureServiceMonitorResourceAbsent", reflect.TypeOf((*MockRouteMonitorDeleter)(nil).EnsureServiceMonitorResourceAbsent), ctx, routeMonitor)
 }
 
+// EnsureStaleServiceMonitorResourcesAbsent mocks base method
+func (m *MockRouteMonitorDeleter) EnsureStaleServiceMonitorResourcesAbsent(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureStaleServiceMonitorResourcesAbsent", ctx, routeMonitor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureStaleServiceMonitorResourcesAbsent indicates an expected call of EnsureStaleServiceMonitorResourcesAbsent
+func (mr *MockRouteMonitorDeleterMockRecorder) EnsureStaleServiceMonitorResourcesAbsent(ctx, routeMonitor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureStaleServiceMonitorResourcesAbsent", reflect.TypeOf((*MockRouteMonitorDeleter)(nil).EnsureStaleServiceMonitorResourcesAbsent), ctx, routeMonitor)
+}
+
 // MockRouteMonitorAdder is a mock of RouteMonitorAdder interface
 type MockRouteMonitorAdder struct {
 	ctrl     *gomock.Controller
@@ -202,18 +287,46 @@ func (m *MockRouteMonitorAdder) EXPECT() *MockRouteMonitorAdderMockRecorder {
 	return m.recorder
 }
 
+// EnsureBlackBoxExporterConfigMapExists mocks base method
+func (m *MockRouteMonitorAdder) EnsureBlackBoxExporterConfigMapExists(ctx context.Context, config v1alpha1.RouteMonitorOperatorConfigSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureBlackBoxExporterConfigMapExists", ctx, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureBlackBoxExporterConfigMapExists indicates an expected call of EnsureBlackBoxExporterConfigMapExists
+func (mr *MockRouteMonitorAdderMockRecorder) EnsureBlackBoxExporterConfigMapExists(ctx, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterConfigMapExists", reflect.TypeOf((*MockRouteMonitorAdder)(nil).EnsureBlackBoxExporterConfigMapExists), ctx, config)
+}
+
+// EnsureBlackBoxExporterServiceAccountExists mocks base method
+func (m *MockRouteMonitorAdder) EnsureBlackBoxExporterServiceAccountExists(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureBlackBoxExporterServiceAccountExists", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureBlackBoxExporterServiceAccountExists indicates an expected call of EnsureBlackBoxExporterServiceAccountExists
+func (mr *MockRouteMonitorAdderMockRecorder) EnsureBlackBoxExporterServiceAccountExists(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterServiceAccountExists", reflect.TypeOf((*MockRouteMonitorAdder)(nil).EnsureBlackBoxExporterServiceAccountExists), ctx)
+}
+
 // EnsureBlackBoxExporterDeploymentExists mocks base method
-func (m *MockRouteMonitorAdder) EnsureBlackBoxExporterDeploymentExists(ctx context.Context) error {
+func (m *MockRouteMonitorAdder) EnsureBlackBoxExporterDeploymentExists(ctx context.Context, config v1alpha1.RouteMonitorOperatorConfigSpec) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnsureBlackBoxExporterDeploymentExists", ctx)
+	ret := m.ctrl.Call(m, "EnsureBlackBoxExporterDeploymentExists", ctx, config)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // EnsureBlackBoxExporterDeploymentExists indicates an expected call of EnsureBlackBoxExporterDeploymentExists
-func (mr *MockRouteMonitorAdderMockRecorder) EnsureBlackBoxExporterDeploymentExists(ctx interface{}) *gomock.Call {
+func (mr *MockRouteMonitorAdderMockRecorder) EnsureBlackBoxExporterDeploymentExists(ctx, config interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterDeploymentExists", reflect.TypeOf((*MockRouteMonitorAdder)(nil).EnsureBlackBoxExporterDeploymentExists), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterDeploymentExists", reflect.TypeOf((*MockRouteMonitorAdder)(nil).EnsureBlackBoxExporterDeploymentExists), ctx, config)
 }
 
 // EnsureBlackBoxExporterServiceExists mocks base method
@@ -230,17 +343,74 @@ func (mr *MockRouteMonitorAdderMockRecorder) EnsureBlackBoxExporterServiceExists
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterServiceExists", reflect.TypeOf((*MockRouteMonitorAdder)(nil).EnsureBlackBoxExporterServiceExists), ctx)
 }
 
+// EnsureBlackBoxExporterPodDisruptionBudgetExists mocks base method
+func (m *MockRouteMonitorAdder) EnsureBlackBoxExporterPodDisruptionBudgetExists(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureBlackBoxExporterPodDisruptionBudgetExists", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureBlackBoxExporterPodDisruptionBudgetExists indicates an expected call of EnsureBlackBoxExporterPodDisruptionBudgetExists
+func (mr *MockRouteMonitorAdderMockRecorder) EnsureBlackBoxExporterPodDisruptionBudgetExists(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterPodDisruptionBudgetExists", reflect.TypeOf((*MockRouteMonitorAdder)(nil).EnsureBlackBoxExporterPodDisruptionBudgetExists), ctx)
+}
+
+// EnsureBlackBoxExporterNetworkPolicyExists mocks base method
+func (m *MockRouteMonitorAdder) EnsureBlackBoxExporterNetworkPolicyExists(ctx context.Context, config v1alpha1.RouteMonitorOperatorConfigSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureBlackBoxExporterNetworkPolicyExists", ctx, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureBlackBoxExporterNetworkPolicyExists indicates an expected call of EnsureBlackBoxExporterNetworkPolicyExists
+func (mr *MockRouteMonitorAdderMockRecorder) EnsureBlackBoxExporterNetworkPolicyExists(ctx, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterNetworkPolicyExists", reflect.TypeOf((*MockRouteMonitorAdder)(nil).EnsureBlackBoxExporterNetworkPolicyExists), ctx, config)
+}
+
 // EnsureServiceMonitorResourceExists mocks base method
-func (m *MockRouteMonitorAdder) EnsureServiceMonitorResourceExists(ctx context.Context, routeMonitor v1alpha1.RouteMonitor) (reconcile.Result, error) {
+func (m *MockRouteMonitorAdder) EnsureServiceMonitorResourceExists(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) (reconcile.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnsureServiceMonitorResourceExists", ctx, routeMonitor)
+	ret := m.ctrl.Call(m, "EnsureServiceMonitorResourceExists", ctx, routeMonitor, config)
 	ret0, _ := ret[0].(reconcile.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EnsureServiceMonitorResourceExists indicates an expected call of EnsureServiceMonitorResourceExists
-func (mr *MockRouteMonitorAdderMockRecorder) EnsureServiceMonitorResourceExists(ctx, routeMonitor interface{}) *gomock.Call {
+func (mr *MockRouteMonitorAdderMockRecorder) EnsureServiceMonitorResourceExists(ctx, routeMonitor, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureServiceMonitorResourceExists", reflect.TypeOf((*MockRouteMonitorAdder)(nil).EnsureServiceMonitorResourceExists), ctx, routeMonitor, config)
+}
+
+// EnsureServiceMonitorResourceUpToDate mocks base method
+func (m *MockRouteMonitorAdder) EnsureServiceMonitorResourceUpToDate(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureServiceMonitorResourceUpToDate", ctx, routeMonitor, config)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureServiceMonitorResourceUpToDate indicates an expected call of EnsureServiceMonitorResourceUpToDate
+func (mr *MockRouteMonitorAdderMockRecorder) EnsureServiceMonitorResourceUpToDate(ctx, routeMonitor, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureServiceMonitorResourceUpToDate", reflect.TypeOf((*MockRouteMonitorAdder)(nil).EnsureServiceMonitorResourceUpToDate), ctx, routeMonitor, config)
+}
+
+// EnsurePrometheusRuleResourceExists mocks base method
+func (m *MockRouteMonitorAdder) EnsurePrometheusRuleResourceExists(ctx context.Context, routeMonitor v1alpha1.RouteMonitor, config v1alpha1.RouteMonitorOperatorConfigSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsurePrometheusRuleResourceExists", ctx, routeMonitor, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsurePrometheusRuleResourceExists indicates an expected call of EnsurePrometheusRuleResourceExists
+func (mr *MockRouteMonitorAdderMockRecorder) EnsurePrometheusRuleResourceExists(ctx, routeMonitor, config interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureServiceMonitorResourceExists", reflect.TypeOf((*MockRouteMonitorAdder)(nil).EnsureServiceMonitorResourceExists), ctx, routeMonitor)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsurePrometheusRuleResourceExists", reflect.TypeOf((*MockRouteMonitorAdder)(nil).EnsurePrometheusRuleResourceExists), ctx, routeMonitor, config)
 }